@@ -0,0 +1,13 @@
+data "solidfire_account_usage" "tenant" {
+  account_id = solidfire_account.tenant.id
+}
+
+output "tenant_chargeback" {
+  value = {
+    volumes           = data.solidfire_account_usage.tenant.volume_count
+    provisioned_bytes = data.solidfire_account_usage.tenant.provisioned_space
+    used_bytes        = data.solidfire_account_usage.tenant.used_space
+    guaranteed_iops   = data.solidfire_account_usage.tenant.total_min_iops
+    dedupe_ratio      = data.solidfire_account_usage.tenant.deduplication
+  }
+}
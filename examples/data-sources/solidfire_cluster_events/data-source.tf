@@ -0,0 +1,5 @@
+data "solidfire_cluster_events" "recent_drive_events" {
+  event_type = "driveEvent"
+  start_time = "2024-01-01T00:00:00Z"
+  max_events = 50
+}
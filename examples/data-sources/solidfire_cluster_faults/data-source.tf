@@ -0,0 +1,17 @@
+data "solidfire_cluster_faults" "blocking" {
+  severities = ["critical", "error"]
+}
+
+resource "solidfire_volume" "app" {
+  name       = "app-data"
+  account_id = solidfire_account.app.account_id
+  total_size = 107374182400
+  enable512e = true
+
+  lifecycle {
+    precondition {
+      condition     = data.solidfire_cluster_faults.blocking.fault_count == 0
+      error_message = "Cluster has unresolved critical or error faults."
+    }
+  }
+}
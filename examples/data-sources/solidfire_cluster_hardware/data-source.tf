@@ -0,0 +1,7 @@
+data "solidfire_cluster_hardware" "nodes" {
+  type = "nodes"
+}
+
+output "node_chassis_serials" {
+  value = { for n in data.solidfire_cluster_hardware.nodes.nodes : n.node_id => n.chassis_serial }
+}
@@ -0,0 +1,7 @@
+data "solidfire_drives" "active" {
+  status = "active"
+}
+
+output "worn_drives" {
+  value = [for d in data.solidfire_drives.active.drives : d.serial if d.life_remaining_percent < 20]
+}
@@ -0,0 +1,18 @@
+data "solidfire_iscsi_sessions" "db" {
+  volume_id     = solidfire_volume.db.id
+  initiator_iqn = "iqn.1998-01.com.vmware:esx01"
+}
+
+check "db_host_logged_in" {
+  assert {
+    condition     = data.solidfire_iscsi_sessions.db.session_count > 0
+    error_message = "esx01 has no iSCSI session to the database volume."
+  }
+}
+
+check "db_sessions_use_chap" {
+  assert {
+    condition     = alltrue([for s in data.solidfire_iscsi_sessions.db.sessions : s.chap_used])
+    error_message = "At least one session to the database volume does not use CHAP."
+  }
+}
@@ -0,0 +1,12 @@
+data "solidfire_nodes" "all" {}
+
+output "node_inventory" {
+  value = {
+    for n in data.solidfire_nodes.all.nodes : n.name => {
+      status           = n.status
+      management_ip    = n.management_ip
+      software_version = n.software_version
+      serial           = n.serial
+    }
+  }
+}
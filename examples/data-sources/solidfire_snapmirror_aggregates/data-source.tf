@@ -0,0 +1,7 @@
+data "solidfire_snapmirror_aggregates" "ontap" {
+  snapmirror_endpoint_id = solidfire_snapmirror_endpoint.ontap.snapmirror_endpoint_id
+}
+
+output "aggregate_free_bytes" {
+  value = { for a in data.solidfire_snapmirror_aggregates.ontap.aggregates : a.name => a.size_available }
+}
@@ -0,0 +1,10 @@
+# Data protection volumes on the ONTAP cluster that can be SnapMirror destinations
+data "solidfire_snapmirror_volumes" "dp" {
+  snapmirror_endpoint_id = solidfire_snapmirror_endpoint.ontap.snapmirror_endpoint_id
+  vserver                = "svm1"
+  type                   = "dp"
+}
+
+output "dp_volumes" {
+  value = data.solidfire_snapmirror_volumes.dp.volumes[*].name
+}
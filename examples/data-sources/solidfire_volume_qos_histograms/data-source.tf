@@ -0,0 +1,16 @@
+data "solidfire_volume_qos_histograms" "db" {
+  volume_ids       = [solidfire_volume.db.id]
+  percentile       = 99
+  headroom_percent = 25
+}
+
+locals {
+  db_qos = one(data.solidfire_volume_qos_histograms.db.volumes[0].recommended_qos)
+}
+
+resource "solidfire_qos_policy" "db" {
+  name       = "db-right-sized"
+  min_iops   = local.db_qos.min_iops
+  max_iops   = local.db_qos.max_iops
+  burst_iops = local.db_qos.burst_iops
+}
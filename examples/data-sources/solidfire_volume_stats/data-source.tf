@@ -0,0 +1,7 @@
+data "solidfire_volume_stats" "db" {
+  volume_ids = [solidfire_volume.db.id]
+}
+
+output "db_qos_utilization" {
+  value = data.solidfire_volume_stats.db.volumes[0].qos_utilization_percent
+}
@@ -0,0 +1,3 @@
+resource "solidfire_cluster_feature" "snapmirror" {
+  feature = "SnapMirror"
+}
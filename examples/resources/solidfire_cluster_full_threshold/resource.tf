@@ -0,0 +1,8 @@
+resource "solidfire_cluster_full_threshold" "this" {
+  stage2_aware_threshold         = 3
+  stage3_block_threshold_percent = 5
+}
+
+output "block_fullness" {
+  value = solidfire_cluster_full_threshold.this.block_fullness
+}
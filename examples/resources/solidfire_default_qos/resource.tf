@@ -0,0 +1,5 @@
+resource "solidfire_default_qos" "this" {
+  min_iops   = 100
+  max_iops   = 10000
+  burst_iops = 12000
+}
@@ -0,0 +1,5 @@
+resource "solidfire_encryption_at_rest" "cluster" {
+  key_provider_id = solidfire_kmip_key_provider.kmip.key_provider_id
+
+  depends_on = [solidfire_kmip_key_server.primary]
+}
@@ -0,0 +1,3 @@
+resource "solidfire_kmip_key_provider" "kmip" {
+  name = "corp-kmip"
+}
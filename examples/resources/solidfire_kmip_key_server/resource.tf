@@ -0,0 +1,9 @@
+resource "solidfire_kmip_key_server" "primary" {
+  name               = "kmip-01"
+  hostnames          = ["kmip-01.example.com", "kmip-02.example.com"]
+  port               = 5696
+  ca_certificate     = file("${path.module}/kmip-ca.pem")
+  client_certificate = file("${path.module}/solidfire-client.pem")
+  key_provider_id    = solidfire_kmip_key_provider.kmip.key_provider_id
+  test_connection    = true
+}
@@ -0,0 +1,19 @@
+# Fail the volumes of an application over to the DR cluster and back as one group.
+# Set active_site = "secondary" to fail over and "primary" to fail back.
+resource "solidfire_replication_failover" "app" {
+  volume_ids = [
+    solidfire_volume_pairing.data.volume_id,
+    solidfire_volume_pairing.logs.volume_id,
+  ]
+  active_site = "primary"
+
+  # Use "unplanned" when the primary cluster is down
+  failover_mode = "planned"
+
+  # A cluster block named "dr" in the provider configuration
+  target_cluster_profile = "dr"
+
+  timeouts {
+    update = "1h"
+  }
+}
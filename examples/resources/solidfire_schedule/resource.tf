@@ -1,6 +1,50 @@
+# Monday to Friday at 02:30
 resource "solidfire_schedule" "daily" {
-  name      = "daily-snapshot"
-  volume_id = solidfire_volume.volume.id
-  minutes   = 0
+  schedule_name = "weekday-snapshot"
+  schedule_type = "Days Of Week"
+  dynamic "weekdays" {
+    for_each = [1, 2, 3, 4, 5]
+    content {
+      day = weekdays.value
+    }
+  }
   hours     = 2
+  minutes   = 30
+  recurring = true
+
+  schedule_info {
+    volume_ids = [solidfire_volume.volume.id]
+    name       = "daily"
+    retention  = "168:00:00"
+  }
+}
+
+# Crash-consistent group snapshots of two volumes every hour, labelled for SnapMirror and replicated to the paired cluster
+resource "solidfire_schedule" "db" {
+  schedule_name = "db-hourly"
+  schedule_type = "Time Interval"
+  hours         = 1
+  recurring     = true
+
+  schedule_info {
+    volume_ids                = [solidfire_volume.data.id, solidfire_volume.log.id]
+    name                      = "db-hourly"
+    retention                 = "24:00:00"
+    snapmirror_label          = "hourly"
+    enable_remote_replication = true
+    ensure_serial_creation    = true
+  }
+}
+
+# On the 1st and 15th of every month at midnight
+resource "solidfire_schedule" "monthly" {
+  schedule_name = "archive"
+  schedule_type = "Days Of Month"
+  monthdays     = [1, 15]
+  recurring     = true
+
+  schedule_info {
+    volume_ids = [solidfire_volume.volume.id]
+    retention  = "2160:00:00"
+  }
 }
@@ -0,0 +1,11 @@
+resource "solidfire_cluster_feature" "snapmirror" {
+  feature = "SnapMirror"
+}
+
+resource "solidfire_snapmirror_endpoint" "ontap" {
+  management_ip = "10.30.30.30"
+  username      = "admin"
+  password      = "password"
+
+  depends_on = [solidfire_cluster_feature.snapmirror]
+}
@@ -0,0 +1,28 @@
+resource "solidfire_volume" "app" {
+  name                          = "app"
+  account_id                    = 1
+  total_size                    = 10737418240
+  enable512e                    = true
+  enable_snapmirror_replication = true
+}
+
+# Replicate the Element volume to an existing ONTAP DP volume
+resource "solidfire_snapmirror_relationship" "app" {
+  snapmirror_endpoint_id = solidfire_snapmirror_endpoint.ontap.snapmirror_endpoint_id
+
+  source_volume {
+    type      = "solidfire"
+    volume_id = solidfire_volume.app.id
+  }
+
+  destination_volume {
+    type    = "ontap"
+    vserver = "svm1"
+    name    = "app_dp"
+  }
+
+  schedule_name = "hourly"
+
+  # Set to "broken" to make the ONTAP volume writable, e.g. for a DR test
+  state = "mirrored"
+}
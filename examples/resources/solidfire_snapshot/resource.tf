@@ -1,4 +1,16 @@
 resource "solidfire_snapshot" "snap1" {
   name      = "my-snapshot"
   volume_id = solidfire_volume.volume.id
+  retention = "168:00:00"
+}
+
+resource "solidfire_snapshot" "group" {
+  name                      = "db-consistent"
+  volume_ids                = [solidfire_volume.data.id, solidfire_volume.log.id]
+  expiration_time           = "2026-12-31T00:00:00Z"
+  enable_remote_replication = true
+  ensure_serial_creation    = true
+  attributes = {
+    owner = "db-team"
+  }
 }
@@ -0,0 +1,16 @@
+resource "solidfire_virtual_network" "tenant_a" {
+  tag     = 210
+  name    = "tenant-a"
+  netmask = "255.255.255.0"
+  svip    = "10.210.0.10"
+
+  address_blocks {
+    start = "10.210.0.11"
+    size  = 4
+  }
+}
+
+resource "solidfire_initiator" "tenant_a_host" {
+  name                = "iqn.1998-01.com.vmware:tenant-a-host01"
+  virtual_network_ids = [solidfire_virtual_network.tenant_a.virtual_network_id]
+}
@@ -0,0 +1,30 @@
+resource "solidfire_volume" "primary" {
+  name       = "primary-vol"
+  account_id = 1
+  total_size = 10737418240
+  enable512e = true
+}
+
+# Pair the volume with a target volume the provider creates on the DR cluster
+resource "solidfire_volume_pairing" "dr" {
+  volume_id = solidfire_volume.primary.id
+  mode      = "Async"
+
+  target_cluster {
+    endpoint = "https://10.20.20.20/json-rpc/12.5"
+    username = "admin"
+    password = "password"
+  }
+
+  target_volume {
+    account_id        = 7
+    min_iops          = 100
+    max_iops          = 1000
+    burst_iops        = 2000
+    delete_on_destroy = true
+  }
+}
+
+output "replication_lag" {
+  value = solidfire_volume_pairing.dr.replication_lag_seconds
+}
@@ -1,8 +1,6 @@
 package solidfire
 
 import (
-	"context"
-
 	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
@@ -20,7 +18,7 @@ func (c *Client) GetAccountByID(id int64) (account, error) {
 		AccountID: id,
 	}
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.GetAccountByID(context.TODO(), &req)
+	res, sdkErr := callSDK(c, "GetAccountByID", (*sdk.SFClient).GetAccountByID, &req)
 	if sdkErr != nil {
 		return account{}, sdkErr
 	}
@@ -33,7 +31,7 @@ func (c *Client) GetAccountByName(name string) (account, error) {
 		Username: name,
 	}
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.GetAccountByName(context.TODO(), &req)
+	res, sdkErr := callSDK(c, "GetAccountByName", (*sdk.SFClient).GetAccountByName, &req)
 	if sdkErr != nil {
 		return account{}, sdkErr
 	}
@@ -44,7 +42,7 @@ func (c *Client) GetAccountByName(name string) (account, error) {
 func (c *Client) ListAccounts() ([]account, error) {
 	req := sdk.ListAccountsRequest{}
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListAccounts(context.TODO(), &req)
+	res, sdkErr := callSDK(c, "ListAccounts", (*sdk.SFClient).ListAccounts, &req)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -0,0 +1,119 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type accountVolume struct {
+	VolumeID  int64  `json:"volumeID"`
+	Status    string `json:"status"`
+	TotalSize int64  `json:"totalSize"`
+	QoS       struct {
+		MinIOPS   int64 `json:"minIOPS"`
+		MaxIOPS   int64 `json:"maxIOPS"`
+		BurstIOPS int64 `json:"burstIOPS"`
+	} `json:"qos"`
+}
+
+type accountVolumeStats struct {
+	AccountID     int64 `json:"accountID"`
+	NonZeroBlocks int64 `json:"nonZeroBlocks"`
+	ZeroBlocks    int64 `json:"zeroBlocks"`
+	VolumeSize    int64 `json:"volumeSize"`
+}
+
+type accountSnapshot struct {
+	SnapshotID int64 `json:"snapshotID"`
+	VolumeID   int64 `json:"volumeID"`
+	TotalSize  int64 `json:"totalSize"`
+}
+
+// ListVolumesForAccountPage returns up to limit volumes of the account, starting at startVolumeID
+func (c *Client) ListVolumesForAccountPage(accountID int64, startVolumeID int64, limit int) ([]accountVolume, error) {
+	raw, err := c.CallAPIMethod("ListVolumesForAccount", map[string]interface{}{
+		"accountID":     accountID,
+		"startVolumeID": startVolumeID,
+		"limit":         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Volumes []accountVolume `json:"volumes"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListVolumesForAccount: %s", err)
+	}
+	return res.Volumes, nil
+}
+
+// pageAccountVolumes calls fetch until a short page is returned and collects every volume
+func pageAccountVolumes(fetch func(startVolumeID int64) ([]accountVolume, error), limit int) ([]accountVolume, error) {
+	var all []accountVolume
+	var start int64
+	for {
+		page, err := fetch(start)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < limit {
+			return all, nil
+		}
+		start = page[len(page)-1].VolumeID + 1
+	}
+}
+
+func (c *Client) GetAccountEfficiency(accountID int64) (*volumeEfficiency, error) {
+	raw, err := c.CallAPIMethod("GetAccountEfficiency", map[string]interface{}{
+		"accountID": accountID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res volumeEfficiency
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing GetAccountEfficiency: %s", err)
+	}
+	return &res, nil
+}
+
+// GetVolumeStatsForAccount returns the aggregated volume stats of an account
+func (c *Client) GetVolumeStatsForAccount(accountID int64) (*accountVolumeStats, error) {
+	raw, err := c.CallAPIMethod("ListVolumeStatsByAccount", map[string]interface{}{
+		"accounts": []int64{accountID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		VolumeStats []accountVolumeStats `json:"volumeStats"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListVolumeStatsByAccount: %s", err)
+	}
+	for _, s := range res.VolumeStats {
+		if s.AccountID == accountID {
+			return &s, nil
+		}
+	}
+	return &accountVolumeStats{AccountID: accountID}, nil
+}
+
+// ListVolumeSnapshots returns the snapshots of one volume
+func (c *Client) ListVolumeSnapshots(volumeID int64) ([]accountSnapshot, error) {
+	raw, err := c.CallAPIMethod("ListSnapshots", map[string]interface{}{
+		"volumeID": volumeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Snapshots []accountSnapshot `json:"snapshots"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListSnapshots: %s", err)
+	}
+	return res.Snapshots, nil
+}
@@ -0,0 +1,97 @@
+package solidfire
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+// httpAPICaller sends Element API calls over the client's HTTP transport. The SDK always connects
+// without verifying the cluster's TLS certificate, so clients that verify it make every call,
+// typed ones included, through this caller.
+type httpAPICaller struct {
+	client *Client
+	http   *http.Client
+}
+
+// verifyTLS makes the client verify the cluster's TLS certificate against caPEM, or the system
+// roots when caPEM is empty
+func (c *Client) verifyTLS(caPEM string) error {
+	config := &tls.Config{}
+	if caPEM != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return fmt.Errorf("ca_certificate contains no PEM encoded certificate")
+		}
+		config.RootCAs = pool
+	}
+	c.useHTTPTransport(&http.Transport{TLSClientConfig: config})
+	return nil
+}
+
+// useHTTPTransport makes the client send its API calls through transport instead of the SDK
+func (c *Client) useHTTPTransport(transport http.RoundTripper) {
+	c.HTTPTransport = transport
+	c.caller = &httpAPICaller{client: c, http: &http.Client{Transport: transport}}
+}
+
+func (h *httpAPICaller) MakeSFCall(ctx context.Context, method string, id int, params interface{}, result interface{}) ([]byte, *sdk.SdkError) {
+	c := h.client
+	body, err := json.Marshal(map[string]interface{}{
+		"method": method,
+		"params": params,
+		"id":     id,
+	})
+	if err != nil {
+		return nil, &sdk.SdkError{Code: "request", Detail: err.Error()}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+c.Host+"/json-rpc/"+c.GetAPIVersion(), bytes.NewReader(body))
+	if err != nil {
+		return nil, &sdk.SdkError{Code: "request", Detail: err.Error()}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.credMu.RLock()
+	req.SetBasicAuth(c.Username, c.Password)
+	c.credMu.RUnlock()
+
+	resp, err := h.http.Do(req)
+	if err != nil {
+		return nil, &sdk.SdkError{Code: "transport", Detail: err.Error()}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &sdk.SdkError{Code: strconv.Itoa(resp.StatusCode), Detail: http.StatusText(resp.StatusCode)}
+	}
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &sdk.SdkError{Code: "transport", Detail: err.Error()}
+	}
+
+	var res struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Name    string `json:"name"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, &sdk.SdkError{Code: "response", Detail: fmt.Sprintf("error parsing %s: %s", method, err)}
+	}
+	if res.Error != nil {
+		return nil, &sdk.SdkError{Code: res.Error.Name, Detail: res.Error.Message}
+	}
+	if result != nil && len(res.Result) > 0 {
+		if err := json.Unmarshal(res.Result, result); err != nil {
+			return nil, &sdk.SdkError{Code: "response", Detail: fmt.Sprintf("error parsing %s: %s", method, err)}
+		}
+	}
+	return res.Result, nil
+}
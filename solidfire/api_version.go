@@ -0,0 +1,110 @@
+package solidfire
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// probeAPIVersion is the API endpoint every Element cluster serves, used to ask for the
+// cluster's own API version
+const probeAPIVersion = "1.0"
+
+// apiFeature is a part of the Element API that older clusters do not have
+type apiFeature struct {
+	name       string
+	minVersion string
+}
+
+var (
+	featureQoSPolicies = apiFeature{name: "QoS policies", minVersion: "10.0"}
+	featureSnapMirror  = apiFeature{name: "SnapMirror", minVersion: "10.1"}
+)
+
+// compareAPIVersions compares dotted API versions such as "9.0" and "12.5" numerically,
+// returning -1, 0 or 1
+func compareAPIVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// negotiateAPIVersion sets the client to the API version the cluster reports through
+// GetClusterVersionInfo, which is the highest one it supports
+func (c *Client) negotiateAPIVersion() error {
+	probe := &Client{
+		Host:                  c.Host,
+		Username:              c.Username,
+		Password:              c.Password,
+		MaxConcurrentRequests: c.MaxConcurrentRequests,
+		RequestsPerSecond:     c.RequestsPerSecond,
+		logCtx:                c.logCtx,
+	}
+	if _, ok := c.caller.(*httpAPICaller); ok {
+		probe.useHTTPTransport(c.HTTPTransport)
+	}
+	probe.SetAPIVersion(probeAPIVersion)
+	info, err := probe.GetClusterVersionInfo()
+	if err != nil {
+		return fmt.Errorf("could not detect the Element API version of %s, set api_version instead: %w", c.Host, err)
+	}
+	if info == nil || info.ClusterAPIVersion == "" {
+		return fmt.Errorf("could not detect the Element API version of %s, set api_version instead: GetClusterVersionInfo returned no clusterAPIVersion", c.Host)
+	}
+	c.SetAPIVersion(info.ClusterAPIVersion)
+	return nil
+}
+
+// requireAPIFeature returns an error naming what (an attribute or resource) when the client's
+// API version is older than feature needs
+func (c *Client) requireAPIFeature(feature apiFeature, what string) error {
+	if compareAPIVersions(c.GetAPIVersion(), feature.minVersion) >= 0 {
+		return nil
+	}
+	return fmt.Errorf("%s needs %s, which requires Element API %s or later, but %s uses API %s",
+		what, feature.name, feature.minVersion, c.Host, c.GetAPIVersion())
+}
+
+// requireAPIFeatures returns a CustomizeDiff function that fails the plan when any of the
+// given attributes is set but the cluster's API version is too old for its feature
+func requireAPIFeatures(attrs map[string]apiFeature) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*Client)
+		if !ok || client == nil {
+			return nil
+		}
+		names := make([]string, 0, len(attrs))
+		for attr := range attrs {
+			names = append(names, attr)
+		}
+		sort.Strings(names)
+		for _, attr := range names {
+			feature := attrs[attr]
+			if _, set := d.GetOk(attr); !set {
+				continue
+			}
+			if err := client.requireAPIFeature(feature, attr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+package solidfire
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompareAPIVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"12.5", "12.5", 0},
+		{"9.0", "10.0", -1},
+		{"12.5", "12.3", 1},
+		{"10", "10.0", 0},
+		{"11.0", "10.1", 1},
+	}
+	for _, c := range cases {
+		if got := compareAPIVersions(c.a, c.b); got != c.want {
+			t.Errorf("compareAPIVersions(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestRequireAPIFeature(t *testing.T) {
+	c := &Client{Host: "10.0.0.1"}
+	c.SetAPIVersion("9.6")
+	err := c.requireAPIFeature(featureQoSPolicies, "qos_policy_id")
+	if err == nil || !strings.Contains(err.Error(), "qos_policy_id") || !strings.Contains(err.Error(), "10.0") {
+		t.Errorf("expected an error naming qos_policy_id and API 10.0, got %v", err)
+	}
+
+	c.SetAPIVersion("12.5")
+	if err := c.requireAPIFeature(featureSnapMirror, "snapmirror_label"); err != nil {
+		t.Errorf("API 12.5 should support SnapMirror, got %v", err)
+	}
+}
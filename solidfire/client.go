@@ -6,38 +6,49 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/scaleoutsean/solidfire-go/sdk"
-	"github.com/sirupsen/logrus"
-	prefixed "github.com/x-cray/logrus-prefixed-formatter"
 )
 
-var ourlog = logrus.WithFields(logrus.Fields{
-	"prefix": "main",
-})
-
-func init() {
-	logrus.SetFormatter(new(prefixed.TextFormatter))
-}
-
 // A Client to interact with the Element API
 type Client struct {
 	Host                  string
 	Username              string
 	Password              string
 	MaxConcurrentRequests int
+	RequestsPerSecond     float64
+	ReadCacheTTL          time.Duration
 	HTTPTransport         http.RoundTripper
 
 	apiVersion string
+	logCtx     context.Context
+	profiles   map[string]*clusterProfile
+
+	initOnce  sync.Once
+	sdkClient *sdk.SFClient
+	caller    apiCaller
+	limiter   *requestLimiter
+	reads     *readCache
+
+	// credentials are read again when the cluster rejects Username and Password. credMu
+	// guards the credentials, sdkClient and logCtx once the client is in use.
+	credentials    *providerCredentials
+	credMu         sync.RWMutex
+	credGeneration int
+}
 
-	initOnce     sync.Once
-	sdkClient    *sdk.SFClient
-	requestSlots chan int
+// apiCaller makes raw Element API calls for CallAPIMethod. It is the SDK client unless the
+// client verifies TLS certificates (see httpAPICaller) or a test sets a fake API; typed SDK
+// calls then go through it too.
+type apiCaller interface {
+	MakeSFCall(ctx context.Context, method string, id int, params interface{}, result interface{}) ([]byte, *sdk.SdkError)
 }
 
 func (c *Client) GetClusterInfo() (*sdk.GetClusterInfoResult, error) {
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.GetClusterInfo(context.TODO())
+	res, sdkErr := callSDKNoRequest(c, "GetClusterInfo", (*sdk.SFClient).GetClusterInfo)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -46,7 +57,7 @@ func (c *Client) GetClusterInfo() (*sdk.GetClusterInfoResult, error) {
 
 func (c *Client) GetClusterVersionInfo() (*sdk.GetClusterVersionInfoResult, error) {
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.GetClusterVersionInfo(context.TODO())
+	res, sdkErr := callSDKNoRequest(c, "GetClusterVersionInfo", (*sdk.SFClient).GetClusterVersionInfo)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -55,51 +66,68 @@ func (c *Client) GetClusterVersionInfo() (*sdk.GetClusterVersionInfoResult, erro
 
 // CallAPIMethod can be used to make a request to any Element API method, receiving results as raw JSON
 func (c *Client) CallAPIMethod(method string, params map[string]interface{}) (*json.RawMessage, error) {
-	c.initOnce.Do(c.init)
-
-	c.waitForAvailableSlot()
-	defer c.releaseSlot()
-
-	ourlog.WithFields(logrus.Fields{
-		"method": method,
-		"params": params,
-	}).Debug("Calling API")
-
-	// This is a bridge method for migration.
-	// We'll eventually replace individual calls with SDK methods.
-	// For now, we can use a generic call if the SDK supports it, or start migrating methods.
-	// Looking at the SDK, it has MakeSFCall which is exported if it was SfClient.MakeSFCall but wait...
-	// base_methods.go: func (sfClient *SFClient) MakeSFCall(...)
-	// It IS exported (starts with Uppercase).
-
-	var res interface{}
-	_, sdkErr := c.sdkClient.MakeSFCall(context.TODO(), method, 1, params, &res)
+	raw, sdkErr := c.callAPIMethod(method, params)
 	if sdkErr != nil {
 		return nil, fmt.Errorf("%s: %s", sdkErr.Code, sdkErr.Detail)
 	}
+	return raw, nil
+}
+
+// callAPIMethod is CallAPIMethod returning the SDK's error, for callers that check its detail
+// the way they do for typed calls
+func (c *Client) callAPIMethod(method string, params map[string]interface{}) (*json.RawMessage, *sdk.SdkError) {
+	res, sdkErr := callWithRetries(c, method, func(sf *sdk.SFClient, ctx context.Context) (interface{}, *sdk.SdkError) {
+		tflog.SubsystemTrace(ctx, logClient, "API call parameters", map[string]interface{}{
+			"params": redactLogValue(params),
+		})
+		var caller apiCaller = sf
+		if c.caller != nil {
+			caller = c.caller
+		}
+		var res interface{}
+		_, sdkErr := caller.MakeSFCall(ctx, method, 1, params, &res)
+		return res, sdkErr
+	})
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
 
 	resultBits, err := json.Marshal(res)
 	if err != nil {
-		return nil, err
+		return nil, &sdk.SdkError{Code: "response", Detail: err.Error()}
 	}
 	rawRes := json.RawMessage(resultBits)
-
-	ourlog.WithFields(logrus.Fields{
-		"method": method,
-	}).Debug("Received successful API response")
 	return &rawRes, nil
 }
 
 func (c *Client) init() {
 	if c.MaxConcurrentRequests == 0 {
-		c.MaxConcurrentRequests = 6
+		c.MaxConcurrentRequests = defaultMaxConcurrentRequests
+	}
+	if c.limiter == nil {
+		c.limiter = newRequestLimiter(c.MaxConcurrentRequests, c.RequestsPerSecond)
 	}
-	c.requestSlots = make(chan int, c.MaxConcurrentRequests)
+	if c.ReadCacheTTL > 0 && c.reads == nil {
+		c.reads = newReadCache(c.ReadCacheTTL)
+	}
+
+	c.sdkClient = c.connectSDK(c.Username, c.Password)
+}
 
-	c.sdkClient = &sdk.SFClient{}
+// connectSDK returns an SDK client for the client's host and API version with the given credentials
+func (c *Client) connectSDK(username, password string) *sdk.SFClient {
+	sf := &sdk.SFClient{}
 	// Note: solidfire-go's Connect method uses SSL and InsecureSkipVerify by default.
 	// It also builds the URL from host and version.
-	c.sdkClient.Connect(context.TODO(), c.Host, c.GetAPIVersion(), c.Username, c.Password)
+	sf.Connect(context.TODO(), c.Host, c.GetAPIVersion(), username, password)
+	return sf
+}
+
+// currentSDKClient returns the SDK client and the generation of the credentials it uses
+func (c *Client) currentSDKClient() (*sdk.SFClient, int) {
+	c.credMu.RLock()
+	defer c.credMu.RUnlock()
+	return c.sdkClient, c.credGeneration
 }
 
 // SetAPIVersion for the client to use for requests to the Element API
@@ -114,11 +142,3 @@ func (c *Client) GetAPIVersion() string {
 	}
 	return c.apiVersion
 }
-
-func (c *Client) waitForAvailableSlot() {
-	c.requestSlots <- 1
-}
-
-func (c *Client) releaseSlot() {
-	<-c.requestSlots
-}
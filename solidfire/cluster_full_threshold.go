@@ -0,0 +1,48 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type clusterFullThreshold struct {
+	BlockFullness                  string `json:"blockFullness"`
+	Fullness                       string `json:"fullness"`
+	MetadataFullness               string `json:"metadataFullness"`
+	MaxMetadataOverProvisionFactor int64  `json:"maxMetadataOverProvisionFactor"`
+	SliceReserveUsedThresholdPct   int64  `json:"sliceReserveUsedThresholdPct"`
+	Stage2AwareThreshold           int64  `json:"stage2AwareThreshold"`
+	Stage2BlockThresholdBytes      int64  `json:"stage2BlockThresholdBytes"`
+	Stage3BlockThresholdBytes      int64  `json:"stage3BlockThresholdBytes"`
+	Stage3BlockThresholdPercent    int64  `json:"stage3BlockThresholdPercent"`
+	Stage3LowThreshold             int64  `json:"stage3LowThreshold"`
+	Stage3MetadataThresholdPercent int64  `json:"stage3MetadataThresholdPercent"`
+	Stage4BlockThresholdBytes      int64  `json:"stage4BlockThresholdBytes"`
+	Stage4CriticalThreshold        int64  `json:"stage4CriticalThreshold"`
+	Stage5BlockThresholdBytes      int64  `json:"stage5BlockThresholdBytes"`
+	Stage2MetadataThresholdBytes   int64  `json:"stage2MetadataThresholdBytes"`
+	Stage3MetadataThresholdBytes   int64  `json:"stage3MetadataThresholdBytes"`
+	Stage4MetadataThresholdBytes   int64  `json:"stage4MetadataThresholdBytes"`
+	Stage5MetadataThresholdBytes   int64  `json:"stage5MetadataThresholdBytes"`
+	SumTotalClusterBytes           int64  `json:"sumTotalClusterBytes"`
+	SumTotalMetadataClusterBytes   int64  `json:"sumTotalMetadataClusterBytes"`
+	SumUsedClusterBytes            int64  `json:"sumUsedClusterBytes"`
+	SumUsedMetadataClusterBytes    int64  `json:"sumUsedMetadataClusterBytes"`
+}
+
+func (c *Client) GetClusterFullThreshold() (*clusterFullThreshold, error) {
+	raw, err := c.CallAPIMethod("GetClusterFullThreshold", nil)
+	if err != nil {
+		return nil, err
+	}
+	var res clusterFullThreshold
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing GetClusterFullThreshold: %s", err)
+	}
+	return &res, nil
+}
+
+func (c *Client) ModifyClusterFullThreshold(params map[string]interface{}) error {
+	_, err := c.CallAPIMethod("ModifyClusterFullThreshold", params)
+	return err
+}
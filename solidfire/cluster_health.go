@@ -0,0 +1,81 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type clusterFault struct {
+	ClusterFaultID      int64           `json:"clusterFaultID"`
+	Code                string          `json:"code"`
+	Details             string          `json:"details"`
+	Date                string          `json:"date"`
+	Resolved            bool            `json:"resolved"`
+	ResolvedDate        string          `json:"resolvedDate"`
+	Severity            string          `json:"severity"`
+	Type                string          `json:"type"`
+	NodeID              int64           `json:"nodeID"`
+	NodeHardwareFaultID int64           `json:"nodeHardwareFaultID"`
+	DriveID             int64           `json:"driveID"`
+	DriveIDs            []int64         `json:"driveIDs"`
+	ServiceID           int64           `json:"serviceID"`
+	NetworkInterface    string          `json:"networkInterface"`
+	Data                json.RawMessage `json:"data"`
+}
+
+type clusterEvent struct {
+	EventID       int64           `json:"eventID"`
+	EventInfoType string          `json:"eventInfoType"`
+	Message       string          `json:"message"`
+	Severity      int64           `json:"severity"`
+	NodeID        int64           `json:"nodeID"`
+	ServiceID     int64           `json:"serviceID"`
+	DriveID       int64           `json:"driveID"`
+	DriveIDs      []int64         `json:"driveIDs"`
+	TimeOfReport  string          `json:"timeOfReport"`
+	TimeOfPublish string          `json:"timeOfPublish"`
+	Details       json.RawMessage `json:"details"`
+}
+
+func (c *Client) ListClusterFaults(faultTypes string, bestPractices bool) ([]clusterFault, error) {
+	raw, err := c.CallAPIMethod("ListClusterFaults", map[string]interface{}{
+		"faultTypes":    faultTypes,
+		"bestPractices": bestPractices,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Faults []clusterFault `json:"faults"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListClusterFaults: %s", err)
+	}
+	return res.Faults, nil
+}
+
+func (c *Client) ListEvents(params map[string]interface{}) ([]clusterEvent, error) {
+	raw, err := c.CallAPIMethod("ListEvents", params)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Events []clusterEvent `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListEvents: %s", err)
+	}
+	return res.Events, nil
+}
+
+// rawJSONString renders free-form API details as a JSON string for state, or "" when absent
+func rawJSONString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
@@ -0,0 +1,105 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// clusterPair is an entry of ListClusterPairs. ClusterUUID and Mvip describe the remote
+// cluster, while ClusterPairUUID is the same on both sides of the pair.
+type clusterPair struct {
+	ClusterPairID   int64  `json:"clusterPairID"`
+	ClusterPairUUID string `json:"clusterPairUUID"`
+	ClusterName     string `json:"clusterName"`
+	ClusterUUID     string `json:"clusterUUID"`
+	Mvip            string `json:"mvip"`
+	Status          string `json:"status"`
+	Version         string `json:"version"`
+	Latency         int64  `json:"latency"`
+}
+
+// clusterIdentity is what GetClusterInfo reports about a cluster to tell it apart from others
+type clusterIdentity struct {
+	Name     string `json:"name"`
+	UniqueID string `json:"uniqueID"`
+	UUID     string `json:"uuid"`
+	Mvip     string `json:"mvip"`
+}
+
+func (c *Client) listClusterPairDetails() ([]clusterPair, error) {
+	raw, err := c.CallAPIMethod("ListClusterPairs", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		ClusterPairs []clusterPair `json:"clusterPairs"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListClusterPairs: %s", err)
+	}
+	return res.ClusterPairs, nil
+}
+
+func (c *Client) getClusterIdentity() (clusterIdentity, error) {
+	raw, err := c.CallAPIMethod("GetClusterInfo", map[string]interface{}{})
+	if err != nil {
+		return clusterIdentity{}, err
+	}
+	var res struct {
+		ClusterInfo clusterIdentity `json:"clusterInfo"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return clusterIdentity{}, fmt.Errorf("error parsing GetClusterInfo: %s", err)
+	}
+	return res.ClusterInfo, nil
+}
+
+// pointsAt reports whether the remote end of the pair is the given cluster. The cluster UUID
+// is compared when both sides know it, the MVIP otherwise.
+func (p clusterPair) pointsAt(cluster clusterIdentity) bool {
+	if p.ClusterUUID != "" && cluster.UUID != "" {
+		return strings.EqualFold(p.ClusterUUID, cluster.UUID)
+	}
+	return p.Mvip != "" && p.Mvip == cluster.Mvip
+}
+
+// findClusterPair returns the pair to the given remote cluster, nil when there is none and an
+// error when more than one pair points at it
+func findClusterPair(pairs []clusterPair, remote clusterIdentity) (*clusterPair, error) {
+	var found *clusterPair
+	for i := range pairs {
+		if !pairs[i].pointsAt(remote) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("cluster pairs %d and %d both point at cluster %s (%s), remove one of them",
+				found.ClusterPairID, pairs[i].ClusterPairID, remote.Name, remote.Mvip)
+		}
+		found = &pairs[i]
+	}
+	return found, nil
+}
+
+// findClusterPairByID returns the pair with the given local ID, or nil
+func findClusterPairByID(pairs []clusterPair, id int64) *clusterPair {
+	for i := range pairs {
+		if pairs[i].ClusterPairID == id {
+			return &pairs[i]
+		}
+	}
+	return nil
+}
+
+// findClusterPairByUUID returns the pair with the given pair UUID, or nil
+func findClusterPairByUUID(pairs []clusterPair, pairUUID string) *clusterPair {
+	if pairUUID == "" {
+		return nil
+	}
+	for i := range pairs {
+		if strings.EqualFold(pairs[i].ClusterPairUUID, pairUUID) {
+			return &pairs[i]
+		}
+	}
+	return nil
+}
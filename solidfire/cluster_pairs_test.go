@@ -0,0 +1,91 @@
+package solidfire
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func TestFindClusterPair(t *testing.T) {
+	dr := clusterIdentity{Name: "dr", UUID: "6f3e8a1c-0d1b-4c3e-9a52-000000000002", Mvip: "10.20.20.20"}
+	pairs := []clusterPair{
+		{ClusterPairID: 1, ClusterName: "lab", ClusterUUID: "6f3e8a1c-0d1b-4c3e-9a52-000000000003", Mvip: "10.30.30.30", Status: "Connected"},
+		{ClusterPairID: 2, ClusterName: "dr", ClusterUUID: "6F3E8A1C-0D1B-4C3E-9A52-000000000002", Mvip: "10.20.20.21", Status: "Connected"},
+	}
+
+	p, err := findClusterPair(pairs, dr)
+	if err != nil || p == nil || p.ClusterPairID != 2 {
+		t.Fatalf("matching by UUID: got %+v, %v; want pair 2", p, err)
+	}
+
+	// without a UUID on the pair the MVIP decides
+	noUUID := []clusterPair{{ClusterPairID: 3, Mvip: "10.20.20.20", Status: "Requested"}}
+	if p, err := findClusterPair(noUUID, dr); err != nil || p == nil || p.ClusterPairID != 3 {
+		t.Errorf("matching by MVIP: got %+v, %v; want pair 3", p, err)
+	}
+
+	if p, err := findClusterPair(pairs[:1], dr); err != nil || p != nil {
+		t.Errorf("no pair to dr: got %+v, %v; want nil, nil", p, err)
+	}
+
+	dup := append(pairs, clusterPair{ClusterPairID: 4, ClusterUUID: dr.UUID})
+	if _, err := findClusterPair(dup, dr); err == nil {
+		t.Error("expected an error for two pairs to the same cluster")
+	}
+}
+
+func TestClusterPairingUpdateChecksTarget(t *testing.T) {
+	const drUUID = "6f3e8a1c-0d1b-4c3e-9a52-000000000002"
+	for _, tc := range []struct {
+		name       string
+		targetUUID string
+		wantErr    bool
+	}{
+		{"same cluster", strings.ToUpper(drUUID), false},
+		{"other cluster", "6f3e8a1c-0d1b-4c3e-9a52-000000000003", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			targetAPI := newFakeAPI()
+			targetAPI.handle("GetClusterInfo", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+				return map[string]interface{}{"clusterInfo": map[string]interface{}{"name": "dr", "uuid": tc.targetUUID}}, nil
+			})
+			profile := &clusterProfile{name: "dr", client: newFakeAPIClient(t, targetAPI)}
+			profile.once.Do(func() {})
+
+			localAPI := newFakeAPI()
+			localAPI.handle("ListClusterPairs", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+				return map[string]interface{}{"clusterPairs": []map[string]interface{}{
+					{"clusterPairID": 5, "clusterName": "dr", "clusterUUID": drUUID, "status": "Connected"},
+				}}, nil
+			})
+			local := newFakeAPIClient(t, localAPI)
+			local.profiles = map[string]*clusterProfile{"dr": profile}
+
+			d := schema.TestResourceDataRaw(t, resourceElementSwClusterPairing().Schema, map[string]interface{}{
+				"target_cluster_profile": "dr",
+			})
+			d.SetId("5")
+			_ = d.Set("cluster_pair_id", 5)
+			_ = d.Set("remote_cluster_uuid", drUUID)
+
+			err := resourceElementSwClusterPairingUpdate(d, local)
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "not the paired cluster") {
+					t.Fatalf("got %v, want an error about the paired cluster", err)
+				}
+				if len(localAPI.called()) != 0 {
+					t.Errorf("source cluster called %v after the check failed", localAPI.called())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if d.Id() != "5" || d.Get("status") != "Connected" {
+				t.Errorf("got id %q status %v, want the pair read back", d.Id(), d.Get("status"))
+			}
+		})
+	}
+}
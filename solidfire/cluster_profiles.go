@@ -0,0 +1,146 @@
+package solidfire
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterProfile is a named cluster from the provider's cluster blocks. Its client is created
+// on first use and then shared by every resource that names the profile, so they share one
+// limiter and read cache.
+type clusterProfile struct {
+	name string
+	conn ClusterConnection
+
+	once   sync.Once
+	client *Client
+	err    error
+}
+
+// clusterProfileSchema is the provider's cluster block
+func clusterProfileSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Named connection profiles for other clusters, referenced by the `*_cluster_profile` attributes of the pairing and failover resources so that their credentials stay out of resource state.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name that resources use to refer to the cluster.",
+				},
+				"endpoint": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "API endpoint of the cluster, e.g. `https://10.20.20.20/json-rpc/12.5`.",
+				},
+				"username": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Cluster administrator.",
+				},
+				"password": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Sensitive:   true,
+					Description: "Password of the cluster administrator.",
+				},
+				"api_version": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Element API version. Taken from a `/json-rpc/VERSION` endpoint path or detected from the cluster when not set.",
+				},
+				"insecure_skip_verify": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Do not verify the cluster's TLS certificate. Defaults to true, as Element clusters use self-signed certificates out of the box. When false, every API call to the cluster verifies it.",
+				},
+				"ca_certificate": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "PEM encoded CA certificate to verify the cluster's certificate with, instead of the system roots. Only used when insecure_skip_verify is false.",
+				},
+			},
+		},
+	}
+}
+
+// expandClusterProfiles reads the provider's cluster blocks
+func expandClusterProfiles(v interface{}) (map[string]*clusterProfile, error) {
+	profiles := map[string]*clusterProfile{}
+	for _, raw := range v.([]interface{}) {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := m["name"].(string)
+		if _, dup := profiles[name]; dup {
+			return nil, fmt.Errorf("cluster profile %q is defined more than once", name)
+		}
+		profiles[name] = &clusterProfile{
+			name: name,
+			conn: ClusterConnection{
+				Endpoint:           m["endpoint"].(string),
+				Username:           m["username"].(string),
+				Password:           m["password"].(string),
+				APIVersion:         m["api_version"].(string),
+				InsecureSkipVerify: m["insecure_skip_verify"].(bool),
+				CACertificate:      m["ca_certificate"].(string),
+			},
+		}
+	}
+	return profiles, nil
+}
+
+// profileClient returns the client of the named cluster profile, creating it on first use
+func (c *Client) profileClient(name string) (*Client, error) {
+	p, ok := c.profiles[name]
+	if !ok {
+		names := make([]string, 0, len(c.profiles))
+		for n := range c.profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("cluster profile %q is not defined in the provider configuration (defined: %s)", name, strings.Join(names, ", "))
+	}
+	p.once.Do(func() {
+		p.client, p.err = newClientFromConn(&p.conn, c)
+		if p.err != nil {
+			p.err = fmt.Errorf("cluster profile %q: %w", name, p.err)
+			return
+		}
+		p.client.ReadCacheTTL = c.ReadCacheTTL
+	})
+	return p.client, p.err
+}
+
+// remoteClusterClient returns a client for the cluster named by the profile attribute or
+// described by the connection block of a resource, or nil when neither is set
+func remoteClusterClient(d *schema.ResourceData, meta interface{}, connKey, profileKey string) (*Client, error) {
+	if name, ok := d.GetOk(profileKey); ok {
+		return meta.(*Client).profileClient(name.(string))
+	}
+	conn := expandClusterConnection(d.Get(connKey))
+	if conn == nil {
+		return nil, nil
+	}
+	return newClientFromConn(conn, meta.(*Client))
+}
+
+// clusterAlternatives makes the connection block connKey and the profile attribute profileKey
+// of a resource alternatives; when required, one of them must be set
+func clusterAlternatives(s map[string]*schema.Schema, connKey, profileKey string, required bool) {
+	if required {
+		s[connKey].ExactlyOneOf = []string{connKey, profileKey}
+		s[profileKey].ExactlyOneOf = []string{connKey, profileKey}
+		return
+	}
+	s[connKey].ConflictsWith = []string{profileKey}
+	s[profileKey].ConflictsWith = []string{connKey}
+}
@@ -0,0 +1,150 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testClusterProfiles(t *testing.T) map[string]*clusterProfile {
+	t.Helper()
+	profiles, err := expandClusterProfiles([]interface{}{
+		map[string]interface{}{
+			"name": "dr", "endpoint": "https://10.20.20.20/json-rpc/12.5", "username": "admin", "password": "drsecret",
+			"api_version": "", "insecure_skip_verify": true, "ca_certificate": "",
+		},
+		map[string]interface{}{
+			"name": "lab", "endpoint": "https://10.30.30.30", "username": "admin", "password": "labsecret",
+			"api_version": "12.3", "insecure_skip_verify": true, "ca_certificate": "",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return profiles
+}
+
+func TestExpandClusterProfilesRejectsDuplicates(t *testing.T) {
+	profile := map[string]interface{}{
+		"name": "dr", "endpoint": "https://10.20.20.20", "username": "admin", "password": "x",
+		"api_version": "12.5", "insecure_skip_verify": true, "ca_certificate": "",
+	}
+	if _, err := expandClusterProfiles([]interface{}{profile, profile}); err == nil {
+		t.Error("expected an error for a profile defined twice")
+	}
+}
+
+func TestProfileClient(t *testing.T) {
+	parent := &Client{Host: "10.10.10.10", MaxConcurrentRequests: 3, RequestsPerSecond: 5, ReadCacheTTL: 0}
+	parent.profiles = testClusterProfiles(t)
+
+	dr, err := parent.profileClient("dr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dr.Host != "10.20.20.20" || dr.GetAPIVersion() != "12.5" || dr.Password != "drsecret" {
+		t.Errorf("unexpected client for profile dr: host %s, API %s", dr.Host, dr.GetAPIVersion())
+	}
+	if dr.MaxConcurrentRequests != 3 || dr.RequestsPerSecond != 5 {
+		t.Errorf("profile client did not take the provider's limits: %d, %v", dr.MaxConcurrentRequests, dr.RequestsPerSecond)
+	}
+	if again, _ := parent.profileClient("dr"); again != dr {
+		t.Error("profile client should be created once and shared")
+	}
+
+	lab, err := parent.profileClient("lab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lab.GetAPIVersion() != "12.3" {
+		t.Errorf("api_version of the profile should win, got %s", lab.GetAPIVersion())
+	}
+
+	_, err = parent.profileClient("prod")
+	if err == nil || !strings.Contains(err.Error(), "defined: dr, lab") {
+		t.Errorf("expected an error listing the defined profiles, got %v", err)
+	}
+}
+
+func TestRemoteClusterClientFromProfile(t *testing.T) {
+	parent := &Client{Host: "10.10.10.10"}
+	parent.profiles = testClusterProfiles(t)
+	r := resourceElementSwReplicationFailover()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"volume_ids":             []interface{}{1},
+		"active_site":            "primary",
+		"target_cluster_profile": "dr",
+	})
+	client, err := remoteClusterClient(d, parent, "target_cluster", "target_cluster_profile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client == nil || client.Host != "10.20.20.20" {
+		t.Errorf("expected the client of profile dr, got %+v", client)
+	}
+	if conn := d.Get("target_cluster").([]interface{}); len(conn) != 0 {
+		t.Errorf("a profile should leave target_cluster (and its password) empty, got %v", conn)
+	}
+}
+
+func TestClusterConnectionVerifiesTLS(t *testing.T) {
+	var methods []string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		methods = append(methods, r.URL.Path+" "+req.Method)
+		if user, pass, _ := r.BasicAuth(); user != "admin" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "result": map[string]interface{}{
+			"clusterInfo": map[string]interface{}{"name": "dr"},
+		}})
+	}))
+	defer srv.Close()
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+	conn := &ClusterConnection{
+		Endpoint:      srv.URL + "/json-rpc/12.5",
+		Username:      "admin",
+		Password:      "secret",
+		CACertificate: caPEM,
+	}
+
+	client, err := newClientFromConn(conn, &Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// typed SDK calls go through the verifying transport too
+	if _, err := client.GetClusterInfo(); err != nil {
+		t.Errorf("certificate signed by ca_certificate was rejected: %s", err)
+	}
+	if _, err := client.CallAPIMethod("GetClusterInfo", nil); err != nil {
+		t.Errorf("raw call failed: %s", err)
+	}
+	if want := []string{"/json-rpc/12.5 GetClusterInfo", "/json-rpc/12.5 GetClusterInfo"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("server saw %v, want %v", methods, want)
+	}
+
+	conn.CACertificate = ""
+	client, err = newClientFromConn(conn, &Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetClusterInfo(); err == nil {
+		t.Error("a self-signed certificate should not verify against the system roots")
+	}
+
+	conn.CACertificate = "not a certificate"
+	if _, err := newClientFromConn(conn, &Client{}); err == nil {
+		t.Error("expected an error for an invalid ca_certificate")
+	}
+}
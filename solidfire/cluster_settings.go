@@ -0,0 +1,63 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type defaultQoS struct {
+	MinIOPS   int64 `json:"minIOPS"`
+	MaxIOPS   int64 `json:"maxIOPS"`
+	BurstIOPS int64 `json:"burstIOPS"`
+}
+
+type featureStatus struct {
+	Feature string `json:"feature"`
+	Enabled bool   `json:"enabled"`
+}
+
+func (c *Client) GetDefaultQoS() (*defaultQoS, error) {
+	raw, err := c.CallAPIMethod("GetDefaultQoS", nil)
+	if err != nil {
+		return nil, err
+	}
+	var res defaultQoS
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing GetDefaultQoS: %s", err)
+	}
+	return &res, nil
+}
+
+func (c *Client) SetDefaultQoS(params map[string]interface{}) error {
+	_, err := c.CallAPIMethod("SetDefaultQoS", params)
+	return err
+}
+
+func (c *Client) EnableFeature(feature string) error {
+	_, err := c.CallAPIMethod("EnableFeature", map[string]interface{}{
+		"feature": feature,
+	})
+	return err
+}
+
+// GetFeatureStatus reports whether a single cluster feature is enabled
+func (c *Client) GetFeatureStatus(feature string) (bool, error) {
+	raw, err := c.CallAPIMethod("GetFeatureStatus", map[string]interface{}{
+		"feature": feature,
+	})
+	if err != nil {
+		return false, err
+	}
+	var res struct {
+		Features []featureStatus `json:"features"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return false, fmt.Errorf("error parsing GetFeatureStatus: %s", err)
+	}
+	for _, f := range res.Features {
+		if f.Feature == feature {
+			return f.Enabled, nil
+		}
+	}
+	return false, nil
+}
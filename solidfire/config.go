@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Config is a struct for user input
@@ -13,6 +14,10 @@ type configStuct struct {
 	Password        string
 	ElementSwServer string
 	APIVersion      string
+
+	MaxConcurrentRequests int
+	RequestsPerSecond     float64
+	ReadCacheTTL          time.Duration
 }
 
 // Client contain the api endpoint
@@ -41,6 +46,10 @@ func (c *configStuct) clientFun() (*Client, error) {
 		Host:     host,
 		Username: c.User,
 		Password: c.Password,
+
+		MaxConcurrentRequests: c.MaxConcurrentRequests,
+		RequestsPerSecond:     c.RequestsPerSecond,
+		ReadCacheTTL:          c.ReadCacheTTL,
 		HTTPTransport: &http.Transport{
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: true},
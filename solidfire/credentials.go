@@ -0,0 +1,245 @@
+package solidfire
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultCredentialsFile    = "~/.solidfire/credentials"
+	defaultCredentialsProfile = "default"
+
+	// credentialProcessTimeout bounds how long a credential_process command may run
+	credentialProcessTimeout = time.Minute
+)
+
+// authErrorMarkers are (lower-case) substrings of errors the cluster returns for rejected credentials
+var authErrorMarkers = []string{"unauthorized", "authentication failed", "invalid credentials", "bad credentials"}
+
+// providerCredentials resolves the provider's username and password. Values set in the
+// provider configuration (or its environment variables) win; the rest comes from
+// credential_process or, without one, from a profile of the credentials file. Both are read
+// again when the cluster rejects the credentials.
+type providerCredentials struct {
+	username string
+	password string
+
+	process string
+	file    string
+	profile string
+}
+
+// refreshable reports whether retrieve can return other credentials than the configured ones
+func (p *providerCredentials) refreshable() bool {
+	return p.username == "" || p.password == ""
+}
+
+func (p *providerCredentials) retrieve() (string, string, error) {
+	if !p.refreshable() {
+		return p.username, p.password, nil
+	}
+
+	var username, password string
+	var err error
+	if p.process != "" {
+		username, password, err = runCredentialProcess(p.process)
+	} else {
+		username, password, err = readCredentialsFile(p.file, p.profile)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	if p.username != "" {
+		username = p.username
+	}
+	if p.password != "" {
+		password = p.password
+	}
+	if username == "" || password == "" {
+		return "", "", fmt.Errorf("no username and password: set them in the provider configuration, with credential_process or in a credentials file profile")
+	}
+	return username, password, nil
+}
+
+// credentialProcessOutput is what a credential_process command prints. Field names match
+// case-insensitively, so {"Username": ..., "Password": ...} works too.
+type credentialProcessOutput struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// runCredentialProcess runs command through the shell and reads credentials from its JSON output
+func runCredentialProcess(command string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialProcessTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", "", fmt.Errorf("credential_process failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var out credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return "", "", fmt.Errorf("error parsing credential_process output: %s", err)
+	}
+	return out.Username, out.Password, nil
+}
+
+// readCredentialsFile returns the username and password of a profile of a credentials file.
+// The file is either INI
+//
+//	[default]
+//	username = admin
+//	password = secret
+//
+// or YAML with one mapping per profile.
+func readCredentialsFile(path, profile string) (string, string, error) {
+	if path == "" {
+		path = defaultCredentialsFile
+	}
+	if profile == "" {
+		profile = defaultCredentialsProfile
+	}
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", "", fmt.Errorf("could not find the home directory for %s: %w", path, err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("could not read credentials file: %w", err)
+	}
+	profiles, err := parseCredentialsFile(data)
+	if err != nil {
+		return "", "", fmt.Errorf("error parsing credentials file %s: %s", path, err)
+	}
+	values, ok := profiles[profile]
+	if !ok {
+		return "", "", fmt.Errorf("credentials file %s has no profile %q", path, profile)
+	}
+	return values["username"], values["password"], nil
+}
+
+// parseCredentialsFile parses an INI or YAML credentials file into profiles of key/value pairs.
+// Files whose first section line starts with "[" are INI.
+func parseCredentialsFile(data []byte) (map[string]map[string]string, error) {
+	if isINICredentials(data) {
+		return parseINICredentials(data)
+	}
+	var profiles map[string]map[string]string
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func isINICredentials(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		return strings.HasPrefix(line, "[")
+	}
+	return false
+}
+
+func parseINICredentials(data []byte) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var section map[string]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			section = map[string]string{}
+			profiles[name] = section
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok || section == nil {
+				return nil, fmt.Errorf("line %d: expected [profile] or key = value", n)
+			}
+			section[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	return profiles, scanner.Err()
+}
+
+// isAuthError reports whether the cluster rejected the client's credentials
+func isAuthError(code, detail string) bool {
+	s := strings.ToLower(code + " " + detail)
+	for _, m := range authErrorMarkers {
+		if strings.Contains(s, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshCredentials reads the credentials again after the cluster rejected the ones of the
+// given generation, and reconnects with them. It reports whether the call should be retried:
+// true when the credentials changed, also when a concurrent call already refreshed them.
+// credential_process may take a while, so other calls are only held up to swap the client.
+func (c *Client) refreshCredentials(generation int) (bool, error) {
+	if c.credentials == nil || !c.credentials.refreshable() {
+		return false, nil
+	}
+	c.credMu.RLock()
+	current, oldUsername, oldPassword := c.credGeneration, c.Username, c.Password
+	c.credMu.RUnlock()
+	if current != generation {
+		return true, nil
+	}
+
+	username, password, err := c.credentials.retrieve()
+	if err != nil {
+		return false, err
+	}
+	if username == oldUsername && password == oldPassword {
+		return false, nil
+	}
+	sf := c.connectSDK(username, password)
+
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+	if c.credGeneration != generation {
+		return true, nil
+	}
+	c.Username, c.Password = username, password
+	if c.logCtx != nil {
+		c.logCtx = maskLogSecrets(c.logCtx, password)
+		tflog.SubsystemInfo(c.logCtx, logClient, "Cluster rejected the credentials, using refreshed credentials", map[string]interface{}{
+			"host": c.Host,
+		})
+	}
+	c.sdkClient = sf
+	c.credGeneration++
+	return true, nil
+}
@@ -0,0 +1,241 @@
+package solidfire
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadCredentialsFile(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		profile string
+		user    string
+		pass    string
+	}{
+		{
+			name:    "ini default",
+			content: "# SolidFire clusters\n[default]\nusername = admin\npassword = \"s3cret\"\n\n[dr]\nusername=dradmin\npassword=drsecret\n",
+			user:    "admin",
+			pass:    "s3cret",
+		},
+		{
+			name:    "ini profile",
+			content: "[default]\nusername = admin\npassword = s3cret\n[dr]\nusername=dradmin\npassword=drsecret\n",
+			profile: "dr",
+			user:    "dradmin",
+			pass:    "drsecret",
+		},
+		{
+			name:    "yaml profile",
+			content: "default:\n  username: admin\n  password: s3cret\ndr:\n  username: dradmin\n  password: \"dr=secret\"\n",
+			profile: "dr",
+			user:    "dradmin",
+			pass:    "dr=secret",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, pass, err := readCredentialsFile(writeCredentialsFile(t, tc.content), tc.profile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user != tc.user || pass != tc.pass {
+				t.Errorf("got %s/%s, want %s/%s", user, pass, tc.user, tc.pass)
+			}
+		})
+	}
+}
+
+func TestReadCredentialsFileErrors(t *testing.T) {
+	path := writeCredentialsFile(t, "[default]\nusername = admin\npassword = s3cret\n")
+	if _, _, err := readCredentialsFile(path, "lab"); err == nil {
+		t.Error("expected an error for a missing profile")
+	}
+	if _, _, err := readCredentialsFile(filepath.Join(t.TempDir(), "missing"), ""); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	bad := writeCredentialsFile(t, "[default]\nusername admin\n")
+	if _, _, err := readCredentialsFile(bad, ""); err == nil {
+		t.Error("expected an error for a malformed INI line")
+	}
+}
+
+func TestProviderCredentialsRetrieve(t *testing.T) {
+	file := writeCredentialsFile(t, "[default]\nusername = fileuser\npassword = filepass\n")
+
+	cases := []struct {
+		name  string
+		creds providerCredentials
+		user  string
+		pass  string
+		err   bool
+	}{
+		{
+			name:  "configured values win",
+			creds: providerCredentials{username: "admin", password: "secret", file: file},
+			user:  "admin",
+			pass:  "secret",
+		},
+		{
+			name:  "file",
+			creds: providerCredentials{file: file},
+			user:  "fileuser",
+			pass:  "filepass",
+		},
+		{
+			name:  "configured username with password from file",
+			creds: providerCredentials{username: "admin", file: file},
+			user:  "admin",
+			pass:  "filepass",
+		},
+		{
+			name:  "credential_process before file",
+			creds: providerCredentials{process: `echo '{"username": "vaultuser", "password": "vaultpass"}'`, file: file},
+			user:  "vaultuser",
+			pass:  "vaultpass",
+		},
+		{
+			name:  "credential_process without password",
+			creds: providerCredentials{process: `echo '{"username": "vaultuser"}'`},
+			err:   true,
+		},
+		{
+			name:  "credential_process failure",
+			creds: providerCredentials{process: "echo denied >&2; exit 3"},
+			err:   true,
+		},
+		{
+			name:  "credential_process output not JSON",
+			creds: providerCredentials{process: "echo vaultuser"},
+			err:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, pass, err := tc.creds.retrieve()
+			if tc.err {
+				if err == nil {
+					t.Errorf("expected an error, got %s/%s", user, pass)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user != tc.user || pass != tc.pass {
+				t.Errorf("got %s/%s, want %s/%s", user, pass, tc.user, tc.pass)
+			}
+		})
+	}
+}
+
+func TestIsAuthError(t *testing.T) {
+	if !isAuthError("401", "Unauthorized") {
+		t.Error("401 Unauthorized should be an authentication error")
+	}
+	if isAuthError("xVolumeIDDoesNotExist", "VolumeID 5 does not exist.") {
+		t.Error("xVolumeIDDoesNotExist is not an authentication error")
+	}
+}
+
+// testAuthAPI returns a fake API whose GetClusterInfo only accepts the password "rotated"
+func testAuthAPI(client **Client) *fakeAPI {
+	api := newFakeAPI()
+	api.handle("GetClusterInfo", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		if (*client).Password != "rotated" {
+			return nil, &sdk.SdkError{Code: "401", Detail: "Unauthorized"}
+		}
+		return map[string]interface{}{"clusterInfo": map[string]interface{}{"name": "cluster1"}}, nil
+	})
+	return api
+}
+
+func TestCallAPIMethodRefreshesCredentials(t *testing.T) {
+	var client *Client
+	api := testAuthAPI(&client)
+	client = newFakeAPIClient(t, api)
+	path := writeCredentialsFile(t, "[default]\nusername = admin\npassword = rotated\n")
+	client.Username, client.Password = "admin", "expired"
+	client.credentials = &providerCredentials{file: path}
+
+	if _, err := client.CallAPIMethod("GetClusterInfo", nil); err != nil {
+		t.Fatal(err)
+	}
+	if client.Password != "rotated" {
+		t.Errorf("password was not refreshed: %s", client.Password)
+	}
+	if calls := api.called(); len(calls) != 2 {
+		t.Errorf("expected the call to be retried once, got %v", calls)
+	}
+}
+
+func TestCallAPIMethodDoesNotRetryUnchangedCredentials(t *testing.T) {
+	var client *Client
+	api := testAuthAPI(&client)
+	client = newFakeAPIClient(t, api)
+	path := writeCredentialsFile(t, "[default]\nusername = admin\npassword = expired\n")
+	client.Username, client.Password = "admin", "expired"
+	client.credentials = &providerCredentials{file: path}
+
+	if _, err := client.CallAPIMethod("GetClusterInfo", nil); err == nil {
+		t.Fatal("expected the authentication error")
+	}
+	if calls := api.called(); len(calls) != 1 {
+		t.Errorf("unchanged credentials should not be retried, got %v", calls)
+	}
+
+	// configured credentials are never refreshed
+	client.credentials = &providerCredentials{username: "admin", password: "expired"}
+	if retry, err := client.refreshCredentials(client.credGeneration); retry || err != nil {
+		t.Errorf("configured credentials should not be refreshed: %v, %v", retry, err)
+	}
+}
+
+func TestRefreshCredentialsDoesNotBlockCalls(t *testing.T) {
+	client := newFakeAPIClient(t, newFakeAPI())
+	client.Username, client.Password = "admin", "expired"
+	started := filepath.Join(t.TempDir(), "started")
+	client.credentials = &providerCredentials{
+		process: "touch " + started + `; sleep 1; echo '{"username": "admin", "password": "rotated"}'`,
+	}
+
+	done := make(chan bool)
+	go func() {
+		retry, _ := client.refreshCredentials(client.credGeneration)
+		done <- retry
+	}()
+	for {
+		if _, err := os.Stat(started); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// other calls read the credentials while credential_process runs
+	read := make(chan struct{})
+	go func() {
+		client.currentSDKClient()
+		close(read)
+	}()
+	select {
+	case <-read:
+	case <-time.After(500 * time.Millisecond):
+		t.Error("reading the SDK client blocked while credential_process ran")
+	}
+	if !<-done || client.Password != "rotated" {
+		t.Errorf("credentials were not refreshed: %s", client.Password)
+	}
+}
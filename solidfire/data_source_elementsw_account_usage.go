@@ -0,0 +1,149 @@
+package solidfire
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceElementSwAccountUsage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceElementSwAccountUsageRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"page_size": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  500,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 1 {
+						errors = append(errors, fmt.Errorf("%q must be at least 1", k))
+					}
+					return
+				},
+				Description: "Number of volumes requested per ListVolumesForAccount call.",
+			},
+
+			"volume_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of active volumes owned by the account.",
+			},
+			"provisioned_space": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Sum of the sizes of the account's active volumes in bytes.",
+			},
+			"used_space": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Bytes written to the account's volumes (non-zero blocks), before efficiency.",
+			},
+			"snapshot_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"snapshot_volume_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Sum of the source volume size of each of the account's snapshots in bytes (the snapshot's `totalSize`). This is the most the snapshots can reference, not the space they use, which Element does not report per account.",
+			},
+			"total_min_iops": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Sum of the minimum IOPS guaranteed to the account's active volumes.",
+			},
+			"total_max_iops": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"total_burst_iops": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"compression": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Compression ratio of the account's volumes from GetAccountEfficiency.",
+			},
+			"deduplication": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Deduplication ratio of the account's volumes from GetAccountEfficiency.",
+			},
+			"thin_provisioning": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Thin provisioning ratio of the account's volumes from GetAccountEfficiency.",
+			},
+		},
+	}
+}
+
+func dataSourceElementSwAccountUsageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	accountID := int64(d.Get("account_id").(int))
+	pageSize := d.Get("page_size").(int)
+
+	volumes, err := pageAccountVolumes(func(start int64) ([]accountVolume, error) {
+		return client.ListVolumesForAccountPage(accountID, start, pageSize)
+	}, pageSize)
+	if err != nil {
+		return fmt.Errorf("error calling ListVolumesForAccount: %s", err)
+	}
+
+	var volumeCount, provisioned, minIOPS, maxIOPS, burstIOPS int64
+	var snapshotCount, snapshotVolumeSize int64
+	for _, v := range volumes {
+		// deleted volumes awaiting purge are still listed, but no longer billable
+		if v.Status != "active" {
+			continue
+		}
+		// snapshots are listed per volume rather than cluster-wide for every account
+		snapshots, err := client.ListVolumeSnapshots(v.VolumeID)
+		if err != nil {
+			return fmt.Errorf("error calling ListSnapshots: %s", err)
+		}
+		for _, s := range snapshots {
+			snapshotCount++
+			snapshotVolumeSize += s.TotalSize
+		}
+		volumeCount++
+		provisioned += v.TotalSize
+		minIOPS += v.QoS.MinIOPS
+		maxIOPS += v.QoS.MaxIOPS
+		burstIOPS += v.QoS.BurstIOPS
+	}
+
+	stats, err := client.GetVolumeStatsForAccount(accountID)
+	if err != nil {
+		return fmt.Errorf("error calling ListVolumeStatsByAccount: %s", err)
+	}
+
+	d.SetId(strconv.FormatInt(accountID, 10))
+	d.Set("volume_count", int(volumeCount))
+	d.Set("provisioned_space", int(provisioned))
+	d.Set("used_space", int(stats.NonZeroBlocks*4096))
+	d.Set("snapshot_count", int(snapshotCount))
+	d.Set("snapshot_volume_size", int(snapshotVolumeSize))
+	d.Set("total_min_iops", int(minIOPS))
+	d.Set("total_max_iops", int(maxIOPS))
+	d.Set("total_burst_iops", int(burstIOPS))
+
+	// An account without volumes has no efficiency to report
+	if volumeCount > 0 {
+		eff, err := client.GetAccountEfficiency(accountID)
+		if err != nil {
+			return fmt.Errorf("error calling GetAccountEfficiency: %s", err)
+		}
+		d.Set("compression", eff.Compression)
+		d.Set("deduplication", eff.Deduplication)
+		d.Set("thin_provisioning", eff.ThinProvisioning)
+	}
+
+	return nil
+}
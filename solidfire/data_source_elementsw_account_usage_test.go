@@ -0,0 +1,129 @@
+package solidfire
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func TestPageAccountVolumes(t *testing.T) {
+	var volumes []accountVolume
+	for _, id := range []int64{3, 5, 8, 9, 12} {
+		volumes = append(volumes, accountVolume{VolumeID: id})
+	}
+
+	var starts []int64
+	fetch := func(start int64) ([]accountVolume, error) {
+		starts = append(starts, start)
+		var page []accountVolume
+		for _, v := range volumes {
+			if v.VolumeID >= start && len(page) < 2 {
+				page = append(page, v)
+			}
+		}
+		return page, nil
+	}
+
+	got, err := pageAccountVolumes(fetch, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(volumes) {
+		t.Fatalf("got %d volumes, want %d", len(got), len(volumes))
+	}
+	if fmt.Sprint(starts) != "[0 6 10]" {
+		t.Errorf("unexpected page starts: %v", starts)
+	}
+
+	_, err = pageAccountVolumes(func(int64) ([]accountVolume, error) {
+		return nil, fmt.Errorf("boom")
+	}, 2)
+	if err == nil {
+		t.Error("expected the fetch error to be returned")
+	}
+}
+
+func TestAccountUsageSnapshotsPerVolume(t *testing.T) {
+	api := newFakeAPI()
+	api.handle("ListVolumesForAccount", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{"volumes": []interface{}{
+			map[string]interface{}{"volumeID": 3, "status": "active", "totalSize": 1000},
+			map[string]interface{}{"volumeID": 4, "status": "deleted", "totalSize": 2000},
+		}}, nil
+	})
+	api.handle("ListVolumeStatsByAccount", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{"volumeStats": []interface{}{}}, nil
+	})
+	var listed []interface{}
+	api.handle("ListSnapshots", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		listed = append(listed, p["volumeID"])
+		return map[string]interface{}{"snapshots": []interface{}{
+			map[string]interface{}{"snapshotID": 7, "volumeID": 3, "totalSize": 1000},
+			map[string]interface{}{"snapshotID": 8, "volumeID": 3, "totalSize": 1000},
+		}}, nil
+	})
+	api.handle("GetAccountEfficiency", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{"compression": 1.5}, nil
+	})
+	client := newFakeAPIClient(t, api)
+	r := dataSourceElementSwAccountUsage()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"account_id": 1})
+	if err := r.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(listed) != "[3]" {
+		t.Errorf("expected snapshots to be listed for volume 3 only, got %v", listed)
+	}
+	if got := d.Get("snapshot_count").(int); got != 2 {
+		t.Errorf("snapshot_count = %d, want 2", got)
+	}
+	if got := d.Get("snapshot_volume_size").(int); got != 2000 {
+		t.Errorf("snapshot_volume_size = %d, want 2000", got)
+	}
+}
+
+func TestAccDataSourceElementSwAccountUsage_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceElementSwAccountUsageConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.solidfire_account_usage.test", "volume_count", "2"),
+					resource.TestCheckResourceAttr("data.solidfire_account_usage.test", "provisioned_space", "2147483648"),
+					resource.TestCheckResourceAttr("data.solidfire_account_usage.test", "total_min_iops", "200"),
+					resource.TestCheckResourceAttr("data.solidfire_account_usage.test", "total_max_iops", "2000"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceElementSwAccountUsageConfig = `
+resource "solidfire_account" "test" {
+  username = "terraform-acceptance-account-usage"
+}
+
+resource "solidfire_volume" "test" {
+  count      = 2
+  name       = "terraform-acceptance-account-usage-${count.index}"
+  account_id = solidfire_account.test.id
+  total_size = 1073741824
+  enable512e = true
+  min_iops   = 100
+  max_iops   = 1000
+  burst_iops = 1500
+}
+
+data "solidfire_account_usage" "test" {
+  account_id = solidfire_account.test.id
+  page_size  = 1
+
+  depends_on = [solidfire_volume.test]
+}
+`
@@ -0,0 +1,126 @@
+package solidfire
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func validateRFC3339(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an RFC 3339 timestamp (e.g. 2024-01-02T15:04:05Z): %s", k, err))
+	}
+	return
+}
+
+func dataSourceElementSwClusterEvents() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceElementSwClusterEventsRead,
+		Schema: map[string]*schema.Schema{
+			"start_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRFC3339,
+				Description:  "Only return events reported at or after this time (RFC 3339).",
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRFC3339,
+				Description:  "Only return events reported at or before this time (RFC 3339).",
+			},
+			"event_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return events of this type (for example apiEvent, driveEvent, sliceEvent, tsEvent).",
+			},
+			"node_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return events for this node.",
+			},
+			"max_events": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     100,
+				Description: "Maximum number of events to return.",
+			},
+
+			"event_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Events matching the filters, newest first as returned by the cluster.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"event_id":        {Type: schema.TypeInt, Computed: true},
+						"event_info_type": {Type: schema.TypeString, Computed: true},
+						"message":         {Type: schema.TypeString, Computed: true},
+						"severity":        {Type: schema.TypeInt, Computed: true},
+						"node_id":         {Type: schema.TypeInt, Computed: true},
+						"service_id":      {Type: schema.TypeInt, Computed: true},
+						"drive_id":        {Type: schema.TypeInt, Computed: true},
+						"drive_ids":       {Type: schema.TypeList, Computed: true, Elem: &schema.Schema{Type: schema.TypeInt}},
+						"time_of_report":  {Type: schema.TypeString, Computed: true},
+						"time_of_publish": {Type: schema.TypeString, Computed: true},
+						"details":         {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElementSwClusterEventsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	params := map[string]interface{}{
+		"maxEvents": d.Get("max_events").(int),
+	}
+	if v, ok := d.GetOk("start_time"); ok {
+		params["startReportTime"] = v.(string)
+	}
+	if v, ok := d.GetOk("end_time"); ok {
+		params["endReportTime"] = v.(string)
+	}
+	if v, ok := d.GetOk("event_type"); ok {
+		params["eventType"] = v.(string)
+	}
+	if v, ok := d.GetOk("node_id"); ok {
+		params["nodeID"] = v.(int)
+	}
+
+	events, err := client.ListEvents(params)
+	if err != nil {
+		return fmt.Errorf("error calling ListEvents: %s", err)
+	}
+
+	list := make([]interface{}, 0, len(events))
+	for _, e := range events {
+		list = append(list, map[string]interface{}{
+			"event_id":        int(e.EventID),
+			"event_info_type": e.EventInfoType,
+			"message":         e.Message,
+			"severity":        int(e.Severity),
+			"node_id":         int(e.NodeID),
+			"service_id":      int(e.ServiceID),
+			"drive_id":        int(e.DriveID),
+			"drive_ids":       e.DriveIDs,
+			"time_of_report":  e.TimeOfReport,
+			"time_of_publish": e.TimeOfPublish,
+			"details":         rawJSONString(e.Details),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("events-%s-%s-%s", d.Get("start_time").(string), d.Get("end_time").(string), d.Get("event_type").(string)))
+	if err := d.Set("events", list); err != nil {
+		return err
+	}
+	d.Set("event_count", len(events))
+
+	return nil
+}
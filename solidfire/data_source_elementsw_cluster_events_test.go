@@ -0,0 +1,29 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceElementSwClusterEvents_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceElementSwClusterEventsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.solidfire_cluster_events.test", "event_count"),
+					resource.TestCheckResourceAttr("data.solidfire_cluster_events.test", "max_events", "10"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceElementSwClusterEventsConfig = `
+data "solidfire_cluster_events" "test" {
+  max_events = 10
+}
+`
@@ -0,0 +1,163 @@
+package solidfire
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceElementSwClusterFaults() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceElementSwClusterFaultsRead,
+		Schema: map[string]*schema.Schema{
+			"fault_types": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "current",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(string)
+					if value != "current" && value != "resolved" && value != "all" {
+						errors = append(errors, fmt.Errorf("%q must be one of current, resolved or all", k))
+					}
+					return
+				},
+				Description: "Which faults to list: current, resolved or all.",
+			},
+			"best_practices": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Include faults triggered by sub-optimal configuration (severity bestPractice).",
+			},
+			"severities": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only return faults with one of these severities (warning, error, critical, bestPractice).",
+			},
+			"types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only return faults of one of these types (node, drive, cluster, service, volume).",
+			},
+			"node_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return faults reported for this node.",
+			},
+
+			"fault_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of faults matching the filters.",
+			},
+			"critical_count": {Type: schema.TypeInt, Computed: true},
+			"error_count":    {Type: schema.TypeInt, Computed: true},
+			"warning_count":  {Type: schema.TypeInt, Computed: true},
+			"faults": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Faults matching the filters, ordered by fault ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_fault_id":       {Type: schema.TypeInt, Computed: true},
+						"code":                   {Type: schema.TypeString, Computed: true},
+						"details":                {Type: schema.TypeString, Computed: true},
+						"date":                   {Type: schema.TypeString, Computed: true},
+						"resolved":               {Type: schema.TypeBool, Computed: true},
+						"resolved_date":          {Type: schema.TypeString, Computed: true},
+						"severity":               {Type: schema.TypeString, Computed: true},
+						"type":                   {Type: schema.TypeString, Computed: true},
+						"node_id":                {Type: schema.TypeInt, Computed: true},
+						"node_hardware_fault_id": {Type: schema.TypeInt, Computed: true},
+						"drive_id":               {Type: schema.TypeInt, Computed: true},
+						"drive_ids":              {Type: schema.TypeList, Computed: true, Elem: &schema.Schema{Type: schema.TypeInt}},
+						"service_id":             {Type: schema.TypeInt, Computed: true},
+						"network_interface":      {Type: schema.TypeString, Computed: true},
+						"data":                   {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// filterClusterFaults applies the client-side filters that ListClusterFaults does not support
+func filterClusterFaults(faults []clusterFault, severities, types []string, nodeID int64) []clusterFault {
+	match := func(list []string, value string) bool {
+		if len(list) == 0 {
+			return true
+		}
+		for _, v := range list {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var out []clusterFault
+	for _, f := range faults {
+		if !match(severities, f.Severity) || !match(types, f.Type) {
+			continue
+		}
+		if nodeID != 0 && f.NodeID != nodeID {
+			continue
+		}
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ClusterFaultID < out[j].ClusterFaultID })
+	return out
+}
+
+func dataSourceElementSwClusterFaultsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	faultTypes := d.Get("fault_types").(string)
+
+	faults, err := client.ListClusterFaults(faultTypes, d.Get("best_practices").(bool))
+	if err != nil {
+		return fmt.Errorf("error calling ListClusterFaults: %s", err)
+	}
+
+	faults = filterClusterFaults(faults,
+		toStringSlice(d.Get("severities")),
+		toStringSlice(d.Get("types")),
+		int64(d.Get("node_id").(int)))
+
+	counts := map[string]int{}
+	list := make([]interface{}, 0, len(faults))
+	for _, f := range faults {
+		counts[f.Severity]++
+		list = append(list, map[string]interface{}{
+			"cluster_fault_id":       int(f.ClusterFaultID),
+			"code":                   f.Code,
+			"details":                f.Details,
+			"date":                   f.Date,
+			"resolved":               f.Resolved,
+			"resolved_date":          f.ResolvedDate,
+			"severity":               f.Severity,
+			"type":                   f.Type,
+			"node_id":                int(f.NodeID),
+			"node_hardware_fault_id": int(f.NodeHardwareFaultID),
+			"drive_id":               int(f.DriveID),
+			"drive_ids":              f.DriveIDs,
+			"service_id":             int(f.ServiceID),
+			"network_interface":      f.NetworkInterface,
+			"data":                   rawJSONString(f.Data),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("faults-%s", faultTypes))
+	if err := d.Set("faults", list); err != nil {
+		return err
+	}
+	d.Set("fault_count", len(faults))
+	d.Set("critical_count", counts["critical"])
+	d.Set("error_count", counts["error"])
+	d.Set("warning_count", counts["warning"])
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceElementSwClusterFaults_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceElementSwClusterFaultsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.solidfire_cluster_faults.test", "fault_count"),
+					resource.TestCheckResourceAttrSet("data.solidfire_cluster_faults.test", "critical_count"),
+					resource.TestCheckResourceAttrSet("data.solidfire_cluster_faults.all", "faults.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceElementSwClusterFaultsConfig = `
+data "solidfire_cluster_faults" "test" {
+  severities = ["critical", "error"]
+}
+
+data "solidfire_cluster_faults" "all" {
+  fault_types    = "all"
+  best_practices = true
+}
+`
+
+func TestFilterClusterFaults(t *testing.T) {
+	faults := []clusterFault{
+		{ClusterFaultID: 3, Severity: "warning", Type: "node", NodeID: 1},
+		{ClusterFaultID: 1, Severity: "critical", Type: "drive", NodeID: 2},
+		{ClusterFaultID: 2, Severity: "error", Type: "cluster"},
+	}
+
+	all := filterClusterFaults(faults, nil, nil, 0)
+	if len(all) != 3 || all[0].ClusterFaultID != 1 || all[2].ClusterFaultID != 3 {
+		t.Fatalf("expected all faults sorted by ID, got %+v", all)
+	}
+
+	bySeverity := filterClusterFaults(faults, []string{"Critical", "error"}, nil, 0)
+	if len(bySeverity) != 2 {
+		t.Errorf("expected 2 critical/error faults, got %d", len(bySeverity))
+	}
+
+	byType := filterClusterFaults(faults, nil, []string{"node"}, 0)
+	if len(byType) != 1 || byType[0].ClusterFaultID != 3 {
+		t.Errorf("expected only the node fault, got %+v", byType)
+	}
+
+	byNode := filterClusterFaults(faults, nil, nil, 2)
+	if len(byNode) != 1 || byNode[0].ClusterFaultID != 1 {
+		t.Errorf("expected only the fault on node 2, got %+v", byNode)
+	}
+}
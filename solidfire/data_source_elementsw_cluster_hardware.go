@@ -0,0 +1,138 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceElementSwClusterHardware() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceElementSwClusterHardwareRead,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "all",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					switch v.(string) {
+					case "all", "nodes", "drives":
+					default:
+						errors = append(errors, fmt.Errorf("%q must be one of all, nodes or drives", k))
+					}
+					return
+				},
+				Description: "Which hardware to report on: all, nodes or drives.",
+			},
+
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Hardware details per node, ordered by node ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"node_id":        {Type: schema.TypeInt, Computed: true},
+						"serial":         {Type: schema.TypeString, Computed: true},
+						"chassis_serial": {Type: schema.TypeString, Computed: true},
+						"details": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Full hardware information of the node as JSON. Use jsondecode() to access it.",
+						},
+					},
+				},
+			},
+			"drives": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Hardware details per drive, ordered by key.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key":                    {Type: schema.TypeString, Computed: true},
+						"serial":                 {Type: schema.TypeString, Computed: true},
+						"life_remaining_percent": {Type: schema.TypeInt, Computed: true},
+						"details": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Full hardware information of the drive as JSON. Use jsondecode() to access it.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// sortedHardwareKeys orders GetClusterHardwareInfo map keys numerically where possible
+func sortedHardwareKeys(keys []string) []string {
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.ParseInt(keys[i], 10, 64)
+		b, errB := strconv.ParseInt(keys[j], 10, 64)
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func dataSourceElementSwClusterHardwareRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	infoType := d.Get("type").(string)
+	hw, err := client.GetClusterHardwareInfo(infoType)
+	if err != nil {
+		return fmt.Errorf("error calling GetClusterHardwareInfo: %s", err)
+	}
+
+	serials := hw.nodeSerials(client.logContext())
+	nodeKeys := make([]string, 0, len(hw.Nodes))
+	for k := range hw.Nodes {
+		nodeKeys = append(nodeKeys, k)
+	}
+	nodes := make([]interface{}, 0, len(nodeKeys))
+	for _, k := range sortedHardwareKeys(nodeKeys) {
+		id, _ := strconv.Atoi(k)
+		nodes = append(nodes, map[string]interface{}{
+			"node_id":        id,
+			"serial":         serials[k].NodeSerial,
+			"chassis_serial": serials[k].ChassisSerial,
+			"details":        rawJSONString(hw.Nodes[k]),
+		})
+	}
+
+	driveKeys := make([]string, 0, len(hw.Drives))
+	for k := range hw.Drives {
+		driveKeys = append(driveKeys, k)
+	}
+	drives := make([]interface{}, 0, len(driveKeys))
+	for _, k := range sortedHardwareKeys(driveKeys) {
+		var w driveHardwareWear
+		if err := json.Unmarshal(hw.Drives[k], &w); err != nil {
+			tflog.SubsystemWarn(client.logContext(), logResource, "Unable to parse drive hardware info", map[string]interface{}{
+				"drive": k,
+				"error": err.Error(),
+			})
+		}
+		drives = append(drives, map[string]interface{}{
+			"key":                    k,
+			"serial":                 w.Serial,
+			"life_remaining_percent": int(w.LifeRemainingPercent),
+			"details":                rawJSONString(hw.Drives[k]),
+		})
+	}
+
+	d.SetId("hardware-" + infoType)
+	if err := d.Set("nodes", nodes); err != nil {
+		return err
+	}
+	if err := d.Set("drives", drives); err != nil {
+		return err
+	}
+
+	return nil
+}
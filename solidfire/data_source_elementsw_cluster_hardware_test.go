@@ -0,0 +1,60 @@
+package solidfire
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceElementSwClusterHardware_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceElementSwClusterHardwareConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.solidfire_cluster_hardware.test", "nodes.0.details"),
+					resource.TestCheckResourceAttr("data.solidfire_cluster_hardware.test", "drives.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceElementSwClusterHardwareConfig = `
+data "solidfire_cluster_hardware" "test" {
+  type = "nodes"
+}
+`
+
+func TestClusterHardwareInfoParsing(t *testing.T) {
+	hw := clusterHardwareInfo{
+		Nodes: map[string]json.RawMessage{
+			"1": json.RawMessage(`{"chassisSerial":"CH1","nodeSerial":"N1","fans":{}}`),
+			"2": json.RawMessage(`"not an object"`),
+		},
+		Drives: map[string]json.RawMessage{
+			"10": json.RawMessage(`{"serial":"D10","lifeRemainingPercent":97,"powerOnHours":1200}`),
+			"11": json.RawMessage(`{"lifeRemainingPercent":50}`),
+		},
+	}
+
+	serials := hw.nodeSerials(context.Background())
+	if len(serials) != 1 || serials["1"].ChassisSerial != "CH1" || serials["1"].NodeSerial != "N1" {
+		t.Errorf("unexpected node serials: %+v", serials)
+	}
+
+	wear := hw.driveWear(context.Background())
+	if len(wear) != 1 || wear["D10"].LifeRemainingPercent != 97 || wear["D10"].PowerOnHours != 1200 {
+		t.Errorf("unexpected drive wear: %+v", wear)
+	}
+
+	keys := sortedHardwareKeys([]string{"10", "9", "b", "a", "100"})
+	if strings.Join(keys, ",") != "9,10,100,a,b" {
+		t.Errorf("unexpected key order: %v", keys)
+	}
+}
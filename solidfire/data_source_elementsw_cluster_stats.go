@@ -3,8 +3,6 @@ package solidfire
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -32,24 +30,54 @@ func dataSourceElementSwClusterStats() *schema.Resource {
 			"compression_factor": {
 				Type:        schema.TypeFloat,
 				Computed:    true,
-				Description: "Efficiency ratio (UniqueBlocks / UsedSpace).",
+				Description: "Compression ratio of the unique blocks, as shown in the Element UI.",
+			},
+			"deduplication_factor": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Deduplication ratio of written blocks (including snapshots) to unique blocks, as shown in the Element UI.",
+			},
+			"thin_provisioning_factor": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Thin provisioning ratio of provisioned blocks to written blocks, as shown in the Element UI.",
+			},
+			"efficiency_factor": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Total efficiency (compression x deduplication x thin provisioning).",
 			},
 
-			// Cluster Capacity (Subset of interesting fields)
+			// Cluster Capacity
 			"capacity": {
 				Type:        schema.TypeList,
 				Computed:    true,
 				Description: "Cluster capacity information.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"active_block_space": {Type: schema.TypeInt, Computed: true},
-						"max_iops":           {Type: schema.TypeInt, Computed: true},
-						"max_used_space":     {Type: schema.TypeInt, Computed: true},
-						"provisioned_space":  {Type: schema.TypeInt, Computed: true},
-						"used_space":         {Type: schema.TypeInt, Computed: true},
-						"unique_blocks":      {Type: schema.TypeInt, Computed: true},
-						"zero_blocks":        {Type: schema.TypeInt, Computed: true},
-						"timestamp":          {Type: schema.TypeString, Computed: true},
+						"active_block_space":               {Type: schema.TypeInt, Computed: true},
+						"active_sessions":                  {Type: schema.TypeInt, Computed: true},
+						"average_iops":                     {Type: schema.TypeInt, Computed: true},
+						"cluster_recent_io_size":           {Type: schema.TypeInt, Computed: true},
+						"current_iops":                     {Type: schema.TypeInt, Computed: true},
+						"max_iops":                         {Type: schema.TypeInt, Computed: true},
+						"max_over_provisionable_space":     {Type: schema.TypeInt, Computed: true},
+						"max_provisioned_space":            {Type: schema.TypeInt, Computed: true},
+						"max_used_metadata_space":          {Type: schema.TypeInt, Computed: true},
+						"max_used_space":                   {Type: schema.TypeInt, Computed: true},
+						"non_zero_blocks":                  {Type: schema.TypeInt, Computed: true},
+						"peak_active_sessions":             {Type: schema.TypeInt, Computed: true},
+						"peak_iops":                        {Type: schema.TypeInt, Computed: true},
+						"provisioned_space":                {Type: schema.TypeInt, Computed: true},
+						"snapshot_non_zero_blocks":         {Type: schema.TypeInt, Computed: true},
+						"total_ops":                        {Type: schema.TypeInt, Computed: true},
+						"unique_blocks":                    {Type: schema.TypeInt, Computed: true},
+						"unique_blocks_used_space":         {Type: schema.TypeInt, Computed: true},
+						"used_metadata_space":              {Type: schema.TypeInt, Computed: true},
+						"used_metadata_space_in_snapshots": {Type: schema.TypeInt, Computed: true},
+						"used_space":                       {Type: schema.TypeInt, Computed: true},
+						"zero_blocks":                      {Type: schema.TypeInt, Computed: true},
+						"timestamp":                        {Type: schema.TypeString, Computed: true},
 					},
 				},
 			},
@@ -81,40 +109,83 @@ func dataSourceElementSwClusterStats() *schema.Resource {
 // API Response Structs
 type getClusterStatsResult struct {
 	ClusterStats struct {
-		ActualIOPS         int     `json:"actualIOPS"`
-		AverageIOPSize     int     `json:"averageIOPSize"`
-		ClientQueueDepth   int     `json:"clientQueueDepth"`
+		ActualIOPS         int64   `json:"actualIOPS"`
+		AverageIOPSize     int64   `json:"averageIOPSize"`
+		ClientQueueDepth   int64   `json:"clientQueueDepth"`
 		ClusterUtilization float64 `json:"clusterUtilization"`
-		LatencyUSec        int     `json:"latencyUSec"`
-		ReadBytes          int     `json:"readBytes"`
-		ReadOps            int     `json:"readOps"`
-		WriteBytes         int     `json:"writeBytes"`
-		WriteOps           int     `json:"writeOps"`
+		LatencyUSec        int64   `json:"latencyUSec"`
+		ReadBytes          int64   `json:"readBytes"`
+		ReadOps            int64   `json:"readOps"`
+		WriteBytes         int64   `json:"writeBytes"`
+		WriteOps           int64   `json:"writeOps"`
 		Timestamp          string  `json:"timestamp"`
 	} `json:"clusterStats"`
 }
 
+type clusterCapacity struct {
+	ActiveBlockSpace             int64  `json:"activeBlockSpace"`
+	ActiveSessions               int64  `json:"activeSessions"`
+	AverageIOPS                  int64  `json:"averageIOPS"`
+	ClusterRecentIOSize          int64  `json:"clusterRecentIOSize"`
+	CurrentIOPS                  int64  `json:"currentIOPS"`
+	MaxIOPS                      int64  `json:"maxIOPS"`
+	MaxOverProvisionableSpace    int64  `json:"maxOverProvisionableSpace"`
+	MaxProvisionedSpace          int64  `json:"maxProvisionedSpace"`
+	MaxUsedMetadataSpace         int64  `json:"maxUsedMetadataSpace"`
+	MaxUsedSpace                 int64  `json:"maxUsedSpace"`
+	NonZeroBlocks                int64  `json:"nonZeroBlocks"`
+	PeakActiveSessions           int64  `json:"peakActiveSessions"`
+	PeakIOPS                     int64  `json:"peakIOPS"`
+	ProvisionedSpace             int64  `json:"provisionedSpace"`
+	SnapshotNonZeroBlocks        int64  `json:"snapshotNonZeroBlocks"`
+	TotalOps                     int64  `json:"totalOps"`
+	UniqueBlocks                 int64  `json:"uniqueBlocks"`
+	UniqueBlocksUsedSpace        int64  `json:"uniqueBlocksUsedSpace"`
+	UsedMetadataSpace            int64  `json:"usedMetadataSpace"`
+	UsedMetadataSpaceInSnapshots int64  `json:"usedMetadataSpaceInSnapshots"`
+	UsedSpace                    int64  `json:"usedSpace"`
+	ZeroBlocks                   int64  `json:"zeroBlocks"`
+	Timestamp                    string `json:"timestamp"`
+}
+
 type getClusterCapacityResult struct {
-	ClusterCapacity struct {
-		ActiveBlockSpace int    `json:"activeBlockSpace"`
-		MaxIOPS          int    `json:"maxIOPS"`
-		MaxUsedSpace     int    `json:"maxUsedSpace"`
-		ProvisionedSpace int    `json:"provisionedSpace"`
-		UsedSpace        int    `json:"usedSpace"`
-		UniqueBlocks     int    `json:"uniqueBlocks"`
-		ZeroBlocks       int    `json:"zeroBlocks"`
-		Timestamp        string `json:"timestamp"`
-	} `json:"clusterCapacity"`
+	ClusterCapacity clusterCapacity `json:"clusterCapacity"`
 }
 
 type getLimitsResult struct {
-	VolumeCount int `json:"volumeCount"`
+	VolumeCount int64 `json:"volumeCount"`
 }
 
 type listActiveNodesResult struct {
 	Nodes []interface{} `json:"nodes"`
 }
 
+// clusterEfficiency holds the efficiency factors shown on the Element UI reporting page
+type clusterEfficiency struct {
+	Compression      float64
+	Deduplication    float64
+	ThinProvisioning float64
+	Total            float64
+}
+
+// efficiency computes the efficiency factors the same way the Element UI does.
+// uniqueBlocksUsedSpace includes metadata overhead, which the UI discounts by 7%.
+// A factor is reported as 1 (no savings) while its denominator is still zero.
+func (c clusterCapacity) efficiency() clusterEfficiency {
+	e := clusterEfficiency{Compression: 1, Deduplication: 1, ThinProvisioning: 1}
+	if c.UniqueBlocksUsedSpace > 0 {
+		e.Compression = float64(c.UniqueBlocks*4096) / (float64(c.UniqueBlocksUsedSpace) * 0.93)
+	}
+	if c.UniqueBlocks > 0 {
+		e.Deduplication = float64(c.NonZeroBlocks+c.SnapshotNonZeroBlocks) / float64(c.UniqueBlocks)
+	}
+	if c.NonZeroBlocks > 0 {
+		e.ThinProvisioning = float64(c.NonZeroBlocks+c.ZeroBlocks) / float64(c.NonZeroBlocks)
+	}
+	e.Total = e.Compression * e.Deduplication * e.ThinProvisioning
+	return e
+}
+
 func dataSourceElementSwClusterStatsRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Client)
 
@@ -158,21 +229,27 @@ func dataSourceElementSwClusterStatsRead(d *schema.ResourceData, meta interface{
 		return fmt.Errorf("error parsing ListActiveNodes: %s", err)
 	}
 
+	// 5. GetClusterInfo (for a stable ID)
+	info, err := client.GetClusterInfo()
+	if err != nil {
+		return fmt.Errorf("error calling GetClusterInfo: %s", err)
+	}
+
 	// --- Set State ---
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(info.ClusterInfo.UniqueID)
 
 	// Metrics Block
 	metrics := map[string]interface{}{
-		"actual_iops":         statsRes.ClusterStats.ActualIOPS,
-		"average_iop_size":    statsRes.ClusterStats.AverageIOPSize,
-		"client_queue_depth":  statsRes.ClusterStats.ClientQueueDepth,
+		"actual_iops":         int(statsRes.ClusterStats.ActualIOPS),
+		"average_iop_size":    int(statsRes.ClusterStats.AverageIOPSize),
+		"client_queue_depth":  int(statsRes.ClusterStats.ClientQueueDepth),
 		"cluster_utilization": statsRes.ClusterStats.ClusterUtilization,
-		"latency_usec":        statsRes.ClusterStats.LatencyUSec,
-		"read_bytes":          statsRes.ClusterStats.ReadBytes,
-		"read_ops":            statsRes.ClusterStats.ReadOps,
-		"write_bytes":         statsRes.ClusterStats.WriteBytes,
-		"write_ops":           statsRes.ClusterStats.WriteOps,
+		"latency_usec":        int(statsRes.ClusterStats.LatencyUSec),
+		"read_bytes":          int(statsRes.ClusterStats.ReadBytes),
+		"read_ops":            int(statsRes.ClusterStats.ReadOps),
+		"write_bytes":         int(statsRes.ClusterStats.WriteBytes),
+		"write_ops":           int(statsRes.ClusterStats.WriteOps),
 		"timestamp":           statsRes.ClusterStats.Timestamp,
 	}
 	if err := d.Set("metrics", []interface{}{metrics}); err != nil {
@@ -180,15 +257,31 @@ func dataSourceElementSwClusterStatsRead(d *schema.ResourceData, meta interface{
 	}
 
 	// Capacity Block
+	c := capRes.ClusterCapacity
 	capacity := map[string]interface{}{
-		"active_block_space": capRes.ClusterCapacity.ActiveBlockSpace,
-		"max_iops":           capRes.ClusterCapacity.MaxIOPS,
-		"max_used_space":     capRes.ClusterCapacity.MaxUsedSpace,
-		"provisioned_space":  capRes.ClusterCapacity.ProvisionedSpace,
-		"used_space":         capRes.ClusterCapacity.UsedSpace,
-		"unique_blocks":      capRes.ClusterCapacity.UniqueBlocks,
-		"zero_blocks":        capRes.ClusterCapacity.ZeroBlocks,
-		"timestamp":          capRes.ClusterCapacity.Timestamp,
+		"active_block_space":               int(c.ActiveBlockSpace),
+		"active_sessions":                  int(c.ActiveSessions),
+		"average_iops":                     int(c.AverageIOPS),
+		"cluster_recent_io_size":           int(c.ClusterRecentIOSize),
+		"current_iops":                     int(c.CurrentIOPS),
+		"max_iops":                         int(c.MaxIOPS),
+		"max_over_provisionable_space":     int(c.MaxOverProvisionableSpace),
+		"max_provisioned_space":            int(c.MaxProvisionedSpace),
+		"max_used_metadata_space":          int(c.MaxUsedMetadataSpace),
+		"max_used_space":                   int(c.MaxUsedSpace),
+		"non_zero_blocks":                  int(c.NonZeroBlocks),
+		"peak_active_sessions":             int(c.PeakActiveSessions),
+		"peak_iops":                        int(c.PeakIOPS),
+		"provisioned_space":                int(c.ProvisionedSpace),
+		"snapshot_non_zero_blocks":         int(c.SnapshotNonZeroBlocks),
+		"total_ops":                        int(c.TotalOps),
+		"unique_blocks":                    int(c.UniqueBlocks),
+		"unique_blocks_used_space":         int(c.UniqueBlocksUsedSpace),
+		"used_metadata_space":              int(c.UsedMetadataSpace),
+		"used_metadata_space_in_snapshots": int(c.UsedMetadataSpaceInSnapshots),
+		"used_space":                       int(c.UsedSpace),
+		"zero_blocks":                      int(c.ZeroBlocks),
+		"timestamp":                        c.Timestamp,
 	}
 	if err := d.Set("capacity", []interface{}{capacity}); err != nil {
 		return err
@@ -198,7 +291,7 @@ func dataSourceElementSwClusterStatsRead(d *schema.ResourceData, meta interface{
 	volCount := limitsRes.VolumeCount
 	nodeCount := len(nodesRes.Nodes)
 
-	d.Set("volume_count", volCount)
+	d.Set("volume_count", int(volCount))
 	d.Set("node_count", nodeCount)
 
 	if nodeCount > 0 {
@@ -207,20 +300,12 @@ func dataSourceElementSwClusterStatsRead(d *schema.ResourceData, meta interface{
 		d.Set("volumes_per_node", 0.0)
 	}
 
-	// Compression Factor (Avoid division by zero)
-	if capRes.ClusterCapacity.UsedSpace > 0 {
-		// Note: This is a simplified example. Real efficiency might involve dedupe + compression.
-		// UniqueBlocks * 4096 / UsedSpace is one way, or just exposing raw values.
-		// Here we just do a simple ratio if meaningful, or just leave it to the user.
-		// Let's assume the user wants UniqueBlocks / UsedSpace ratio as a proxy.
-		// Actually, SolidFire reports efficiency in GetClusterStats usually, but let's compute something simple.
-		// Let's just use (Provisioned / Used) as "Thin Provisioning Factor" or similar.
-		// But the user asked for "compression_factor".
-		// Let's stick to what I wrote in the thought process: UniqueBlocks / UsedSpace is not quite right for compression.
-		// Let's just use 0.0 for now or remove it if not strictly calculable from these inputs.
-		// Actually, let's calculate "Efficiency" = Provisioned / Used (Thin Provisioning)
-		d.Set("compression_factor", float64(capRes.ClusterCapacity.ProvisionedSpace)/float64(capRes.ClusterCapacity.UsedSpace))
-	}
+	// Efficiency Factors
+	eff := c.efficiency()
+	d.Set("compression_factor", eff.Compression)
+	d.Set("deduplication_factor", eff.Deduplication)
+	d.Set("thin_provisioning_factor", eff.ThinProvisioning)
+	d.Set("efficiency_factor", eff.Total)
 
 	return nil
 }
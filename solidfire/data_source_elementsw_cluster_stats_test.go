@@ -18,6 +18,8 @@ func TestAccDataSourceElementSwClusterStats_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.solidfire_cluster_stats.test", "node_count"),
 					resource.TestCheckResourceAttrSet("data.solidfire_cluster_stats.test", "capacity.0.used_space"),
 					resource.TestCheckResourceAttrSet("data.solidfire_cluster_stats.test", "metrics.0.actual_iops"),
+					resource.TestCheckResourceAttrSet("data.solidfire_cluster_stats.test", "efficiency_factor"),
+					resource.TestCheckResourceAttrPair("data.solidfire_cluster_stats.test", "id", "data.solidfire_cluster.test", "id"),
 				),
 			},
 		},
@@ -26,4 +28,36 @@ func TestAccDataSourceElementSwClusterStats_basic(t *testing.T) {
 
 const testAccDataSourceElementSwClusterStatsConfig = `
 data "solidfire_cluster_stats" "test" {}
+
+data "solidfire_cluster" "test" {}
 `
+
+func TestClusterCapacityEfficiency(t *testing.T) {
+	c := clusterCapacity{
+		NonZeroBlocks:         3000,
+		ZeroBlocks:            9000,
+		SnapshotNonZeroBlocks: 1000,
+		UniqueBlocks:          2000,
+		UniqueBlocksUsedSpace: 2000 * 4096 / 2,
+	}
+	e := c.efficiency()
+
+	approx := func(got, want float64) bool { return got > want-0.001 && got < want+0.001 }
+	if !approx(e.Compression, 2/0.93) {
+		t.Errorf("compression: got %v, want %v", e.Compression, 2/0.93)
+	}
+	if !approx(e.Deduplication, 2) {
+		t.Errorf("deduplication: got %v, want 2", e.Deduplication)
+	}
+	if !approx(e.ThinProvisioning, 4) {
+		t.Errorf("thin provisioning: got %v, want 4", e.ThinProvisioning)
+	}
+	if !approx(e.Total, e.Compression*8) {
+		t.Errorf("total: got %v, want %v", e.Total, e.Compression*8)
+	}
+
+	empty := clusterCapacity{}.efficiency()
+	if empty.Compression != 1 || empty.Deduplication != 1 || empty.ThinProvisioning != 1 || empty.Total != 1 {
+		t.Errorf("expected neutral factors on an empty cluster, got %+v", empty)
+	}
+}
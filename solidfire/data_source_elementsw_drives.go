@@ -0,0 +1,128 @@
+package solidfire
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceElementSwDrives() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceElementSwDrivesRead,
+		Schema: map[string]*schema.Schema{
+			"node_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return drives in this node.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					switch v.(string) {
+					case "active", "available", "erasing", "failed", "removing":
+					default:
+						errors = append(errors, fmt.Errorf("%q must be one of active, available, erasing, failed or removing", k))
+					}
+					return
+				},
+				Description: "Only return drives with this status (active, available, erasing, failed, removing).",
+			},
+			"include_wear": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Look up drive wear with GetClusterHardwareInfo. This is slow on large clusters.",
+			},
+
+			"drive_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"drives": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"drive_id":     {Type: schema.TypeInt, Computed: true},
+						"node_id":      {Type: schema.TypeInt, Computed: true},
+						"serial":       {Type: schema.TypeString, Computed: true},
+						"slot":         {Type: schema.TypeInt, Computed: true},
+						"chassis_slot": {Type: schema.TypeString, Computed: true},
+						"status":       {Type: schema.TypeString, Computed: true},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Drive type (volume, block or unknown).",
+						},
+						"capacity":        {Type: schema.TypeInt, Computed: true},
+						"usable_capacity": {Type: schema.TypeInt, Computed: true},
+						"life_remaining_percent": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Remaining drive endurance. Only set when include_wear is true.",
+						},
+						"reserve_capacity_percent": {Type: schema.TypeInt, Computed: true},
+						"power_on_hours":           {Type: schema.TypeInt, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElementSwDrivesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	drives, err := client.ListDrives()
+	if err != nil {
+		return fmt.Errorf("error calling ListDrives: %s", err)
+	}
+
+	var wear map[string]driveHardwareWear
+	if d.Get("include_wear").(bool) {
+		hw, err := client.GetClusterHardwareInfo("drives")
+		if err != nil {
+			return fmt.Errorf("error calling GetClusterHardwareInfo: %s", err)
+		}
+		wear = hw.driveWear(client.logContext())
+	}
+
+	nodeID, filterNode := d.GetOk("node_id")
+	status, filterStatus := d.GetOk("status")
+
+	list := make([]interface{}, 0, len(drives))
+	for _, dr := range drives {
+		if filterNode && dr.NodeID != int64(nodeID.(int)) {
+			continue
+		}
+		if filterStatus && dr.Status != status.(string) {
+			continue
+		}
+		drive := map[string]interface{}{
+			"drive_id":        int(dr.DriveID),
+			"node_id":         int(dr.NodeID),
+			"serial":          dr.Serial,
+			"slot":            int(dr.Slot),
+			"chassis_slot":    dr.ChassisSlot,
+			"status":          dr.Status,
+			"type":            dr.Type,
+			"capacity":        int(dr.Capacity),
+			"usable_capacity": int(dr.UsableCapacity),
+		}
+		if w, ok := wear[dr.Serial]; ok {
+			drive["life_remaining_percent"] = int(w.LifeRemainingPercent)
+			drive["reserve_capacity_percent"] = int(w.ReserveCapacityPercent)
+			drive["power_on_hours"] = int(w.PowerOnHours)
+		}
+		list = append(list, drive)
+	}
+
+	d.SetId(fmt.Sprintf("drives-%d-%s", d.Get("node_id").(int), d.Get("status").(string)))
+	if err := d.Set("drives", list); err != nil {
+		return err
+	}
+	d.Set("drive_count", len(list))
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceElementSwDrives_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceElementSwDrivesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.solidfire_drives.test", "drive_count"),
+					resource.TestCheckResourceAttr("data.solidfire_drives.test", "drives.0.status", "active"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceElementSwDrivesConfig = `
+data "solidfire_drives" "test" {
+  status = "active"
+}
+`
@@ -1,7 +1,6 @@
 package solidfire
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 
@@ -59,7 +58,7 @@ func dataSourceElementSwInitiatorRead(d *schema.ResourceData, meta interface{})
 	}
 
 	client.initOnce.Do(client.init)
-	res, sdkErr := client.sdkClient.ListInitiators(context.TODO(), &req)
+	res, sdkErr := callSDK(client, "ListInitiators", (*sdk.SFClient).ListInitiators, &req)
 	if sdkErr != nil {
 		return fmt.Errorf("failed to list initiators: %s", sdkErr.Detail)
 	}
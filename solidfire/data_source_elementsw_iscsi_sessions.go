@@ -0,0 +1,158 @@
+package solidfire
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceElementSwISCSISessions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceElementSwISCSISessionsRead,
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return sessions to this volume.",
+			},
+			"initiator_iqn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return sessions from this initiator IQN (case-insensitive).",
+			},
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return sessions of volumes owned by this account.",
+			},
+
+			"session_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of matching sessions. Use it in check blocks or postconditions to verify that hosts are logged in.",
+			},
+			"sessions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching sessions, ordered by session ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"session_id":    {Type: schema.TypeInt, Computed: true},
+						"account_id":    {Type: schema.TypeInt, Computed: true},
+						"account_name":  {Type: schema.TypeString, Computed: true},
+						"volume_id":     {Type: schema.TypeInt, Computed: true},
+						"initiator_iqn": {Type: schema.TypeString, Computed: true},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the session, `Active` for a logged in host.",
+						},
+						"initiator_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Initiator address and port the session was established from.",
+						},
+						"target_iqn": {Type: schema.TypeString, Computed: true},
+						"target_ip":  {Type: schema.TypeString, Computed: true},
+						"target_node_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the node hosting the session.",
+						},
+						"service_id":         {Type: schema.TypeInt, Computed: true},
+						"virtual_network_id": {Type: schema.TypeInt, Computed: true},
+						"create_time":        {Type: schema.TypeString, Computed: true},
+						"ms_since_last_iscsi_pdu": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Milliseconds since the last iSCSI PDU was received on the session.",
+						},
+						"ms_since_last_scsi_command": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Milliseconds since the last SCSI command was received on the session.",
+						},
+						"auth_method": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Authentication method of the session (CHAP or None).",
+						},
+						"chap_used":      {Type: schema.TypeBool, Computed: true},
+						"chap_username":  {Type: schema.TypeString, Computed: true},
+						"chap_direction": {Type: schema.TypeString, Computed: true},
+						"chap_algorithm": {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// filterISCSISessions returns the sessions matching all non-zero filters, sorted by session ID
+func filterISCSISessions(sessions []iscsiSession, volumeID int64, initiatorIQN string, accountID int64) []iscsiSession {
+	var out []iscsiSession
+	for _, s := range sessions {
+		if volumeID != 0 && s.VolumeID != volumeID {
+			continue
+		}
+		if initiatorIQN != "" && !strings.EqualFold(s.InitiatorName, initiatorIQN) {
+			continue
+		}
+		if accountID != 0 && s.AccountID != accountID {
+			continue
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SessionID < out[j].SessionID })
+	return out
+}
+
+func dataSourceElementSwISCSISessionsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	sessions, err := client.ListISCSISessions()
+	if err != nil {
+		return fmt.Errorf("error calling ListISCSISessions: %s", err)
+	}
+
+	volumeID := int64(d.Get("volume_id").(int))
+	initiatorIQN := d.Get("initiator_iqn").(string)
+	accountID := int64(d.Get("account_id").(int))
+	matched := filterISCSISessions(sessions, volumeID, initiatorIQN, accountID)
+
+	list := make([]interface{}, 0, len(matched))
+	for _, s := range matched {
+		list = append(list, map[string]interface{}{
+			"session_id":                 int(s.SessionID),
+			"account_id":                 int(s.AccountID),
+			"account_name":               s.AccountName,
+			"volume_id":                  int(s.VolumeID),
+			"initiator_iqn":              s.InitiatorName,
+			"state":                      s.state(),
+			"initiator_ip":               s.InitiatorIP,
+			"target_iqn":                 s.TargetName,
+			"target_ip":                  s.TargetIP,
+			"target_node_id":             int(s.NodeID),
+			"service_id":                 int(s.ServiceID),
+			"virtual_network_id":         int(s.VirtualNetworkID),
+			"create_time":                s.CreateTime,
+			"ms_since_last_iscsi_pdu":    int(s.MsSinceLastIscsiPDU),
+			"ms_since_last_scsi_command": int(s.MsSinceLastScsiCommand),
+			"auth_method":                s.Authentication.AuthMethod,
+			"chap_used":                  strings.EqualFold(s.Authentication.AuthMethod, "CHAP"),
+			"chap_username":              s.Authentication.ChapUsername,
+			"chap_direction":             s.Authentication.Direction,
+			"chap_algorithm":             s.Authentication.ChapAlgorithm,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("iscsi-sessions-%d-%s-%d", volumeID, initiatorIQN, accountID))
+	if err := d.Set("sessions", list); err != nil {
+		return err
+	}
+	d.Set("session_count", len(matched))
+
+	return nil
+}
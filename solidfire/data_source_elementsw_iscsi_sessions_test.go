@@ -0,0 +1,70 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestFilterISCSISessions(t *testing.T) {
+	sessions := []iscsiSession{
+		{SessionID: 3, VolumeID: 10, AccountID: 1, InitiatorName: "iqn.1998-01.com.vmware:esx1"},
+		{SessionID: 1, VolumeID: 10, AccountID: 1, InitiatorName: "iqn.1998-01.com.vmware:esx2"},
+		{SessionID: 2, VolumeID: 11, AccountID: 2, InitiatorName: "iqn.1998-01.com.vmware:esx1"},
+	}
+
+	cases := []struct {
+		name      string
+		volumeID  int64
+		iqn       string
+		accountID int64
+		want      []int64
+	}{
+		{"no filters", 0, "", 0, []int64{1, 2, 3}},
+		{"volume", 10, "", 0, []int64{1, 3}},
+		{"initiator case-insensitive", 0, "IQN.1998-01.com.vmware:ESX1", 0, []int64{2, 3}},
+		{"account", 0, "", 2, []int64{2}},
+		{"combined", 10, "iqn.1998-01.com.vmware:esx1", 1, []int64{3}},
+		{"no match", 12, "", 0, nil},
+	}
+	for _, tc := range cases {
+		got := filterISCSISessions(sessions, tc.volumeID, tc.iqn, tc.accountID)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got %d sessions, want %d", tc.name, len(got), len(tc.want))
+			continue
+		}
+		for i, s := range got {
+			if s.SessionID != tc.want[i] {
+				t.Errorf("%s: session %d has ID %d, want %d", tc.name, i, s.SessionID, tc.want[i])
+			}
+		}
+	}
+}
+
+func TestISCSISessionState(t *testing.T) {
+	if got := (iscsiSession{}).state(); got != "Active" {
+		t.Errorf("session without a reported state: got %q, want Active", got)
+	}
+	if got := (iscsiSession{SessionState: "LoggingOut"}).state(); got != "LoggingOut" {
+		t.Errorf("reported state: got %q, want LoggingOut", got)
+	}
+}
+
+func TestAccDataSourceElementSwISCSISessions_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceElementSwISCSISessionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.solidfire_iscsi_sessions.test", "session_count"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceElementSwISCSISessionsConfig = `
+data "solidfire_iscsi_sessions" "test" {}
+`
@@ -0,0 +1,141 @@
+package solidfire
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceElementSwNodes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceElementSwNodesRead,
+		Schema: map[string]*schema.Schema{
+			"include_pending": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Also return nodes that are pending or being added to the cluster.",
+			},
+			"include_serials": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Look up node serial numbers with GetClusterHardwareInfo. This is slow on large clusters.",
+			},
+
+			"node_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Active nodes followed by pending and pending active nodes.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"node_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Node ID. For pending nodes this is the ID the node will get once added (0 if not yet assigned).",
+						},
+						"pending_node_id": {Type: schema.TypeInt, Computed: true},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "One of active, pending or pending_active.",
+						},
+						"name":              {Type: schema.TypeString, Computed: true},
+						"role":              {Type: schema.TypeString, Computed: true},
+						"software_version":  {Type: schema.TypeString, Computed: true},
+						"compatible":        {Type: schema.TypeBool, Computed: true},
+						"management_ip":     {Type: schema.TypeString, Computed: true},
+						"cluster_ip":        {Type: schema.TypeString, Computed: true},
+						"storage_ip":        {Type: schema.TypeString, Computed: true},
+						"uuid":              {Type: schema.TypeString, Computed: true},
+						"chassis_name":      {Type: schema.TypeString, Computed: true},
+						"node_slot":         {Type: schema.TypeString, Computed: true},
+						"node_type":         {Type: schema.TypeString, Computed: true},
+						"chassis_type":      {Type: schema.TypeString, Computed: true},
+						"cpu_model":         {Type: schema.TypeString, Computed: true},
+						"memory_gb":         {Type: schema.TypeInt, Computed: true},
+						"master_service_id": {Type: schema.TypeInt, Computed: true},
+						"serial":            {Type: schema.TypeString, Computed: true},
+						"chassis_serial":    {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenClusterNode(n clusterNode, status string) map[string]interface{} {
+	nodeID := n.NodeID
+	if status != "active" {
+		nodeID = n.AssignedNodeID
+	}
+	return map[string]interface{}{
+		"node_id":           int(nodeID),
+		"pending_node_id":   int(n.PendingNodeID),
+		"status":            status,
+		"name":              n.Name,
+		"role":              n.Role,
+		"software_version":  n.SoftwareVersion,
+		"compatible":        status == "active" || n.Compatible,
+		"management_ip":     n.Mip,
+		"cluster_ip":        n.Cip,
+		"storage_ip":        n.Sip,
+		"uuid":              n.UUID,
+		"chassis_name":      n.ChassisName,
+		"node_slot":         n.NodeSlot,
+		"node_type":         n.PlatformInfo.NodeType,
+		"chassis_type":      n.PlatformInfo.ChassisType,
+		"cpu_model":         n.PlatformInfo.CPUModel,
+		"memory_gb":         int(n.PlatformInfo.NodeMemoryGB),
+		"master_service_id": int(n.AssociatedMasterServiceID),
+	}
+}
+
+func dataSourceElementSwNodesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	res, err := client.ListAllNodes()
+	if err != nil {
+		return fmt.Errorf("error calling ListAllNodes: %s", err)
+	}
+
+	var serials map[string]nodeHardwareSerials
+	if d.Get("include_serials").(bool) {
+		hw, err := client.GetClusterHardwareInfo("nodes")
+		if err != nil {
+			return fmt.Errorf("error calling GetClusterHardwareInfo: %s", err)
+		}
+		serials = hw.nodeSerials(client.logContext())
+	}
+
+	list := make([]interface{}, 0, len(res.Nodes))
+	for _, n := range res.Nodes {
+		node := flattenClusterNode(n, "active")
+		if s, ok := serials[strconv.FormatInt(n.NodeID, 10)]; ok {
+			node["serial"] = s.NodeSerial
+			node["chassis_serial"] = s.ChassisSerial
+		}
+		list = append(list, node)
+	}
+	if d.Get("include_pending").(bool) {
+		for _, n := range res.PendingNodes {
+			list = append(list, flattenClusterNode(n, "pending"))
+		}
+		for _, n := range res.PendingActiveNodes {
+			list = append(list, flattenClusterNode(n, "pending_active"))
+		}
+	}
+
+	d.SetId(fmt.Sprintf("nodes-%t", d.Get("include_pending").(bool)))
+	if err := d.Set("nodes", list); err != nil {
+		return err
+	}
+	d.Set("node_count", len(list))
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceElementSwNodes_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceElementSwNodesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.solidfire_nodes.test", "node_count"),
+					resource.TestCheckResourceAttrSet("data.solidfire_nodes.test", "nodes.0.management_ip"),
+					resource.TestCheckResourceAttr("data.solidfire_nodes.test", "nodes.0.status", "active"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceElementSwNodesConfig = `
+data "solidfire_nodes" "test" {}
+`
@@ -39,6 +39,9 @@ func dataSourceElementSwQosPolicy() *schema.Resource {
 
 func dataSourceElementSwQosPolicyRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Client)
+	if err := client.requireAPIFeature(featureQoSPolicies, "data.solidfire_qos_policy"); err != nil {
+		return err
+	}
 
 	var qosPolicyID int64
 	var name string
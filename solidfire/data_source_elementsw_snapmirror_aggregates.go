@@ -0,0 +1,67 @@
+package solidfire
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceElementSwSnapMirrorAggregates() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceElementSwSnapMirrorAggregatesRead,
+		Schema: map[string]*schema.Schema{
+			"snapmirror_endpoint_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the SnapMirror endpoint of the ONTAP cluster.",
+			},
+
+			"aggregates": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":                  {Type: schema.TypeString, Computed: true},
+						"node_name":             {Type: schema.TypeString, Computed: true},
+						"size_available":        {Type: schema.TypeInt, Computed: true},
+						"size_total":            {Type: schema.TypeInt, Computed: true},
+						"percent_used_capacity": {Type: schema.TypeFloat, Computed: true},
+						"volume_count":          {Type: schema.TypeInt, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElementSwSnapMirrorAggregatesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	if err := client.requireAPIFeature(featureSnapMirror, "data.solidfire_snapmirror_aggregates"); err != nil {
+		return err
+	}
+
+	endpointID := d.Get("snapmirror_endpoint_id").(int)
+	aggregates, err := client.ListSnapMirrorAggregates(int64(endpointID))
+	if err != nil {
+		return fmt.Errorf("error calling ListSnapMirrorAggregates: %s", err)
+	}
+
+	list := make([]interface{}, 0, len(aggregates))
+	for _, a := range aggregates {
+		list = append(list, map[string]interface{}{
+			"name":                  a.AggregateName,
+			"node_name":             a.NodeName,
+			"size_available":        int(a.SizeAvailable),
+			"size_total":            int(a.SizeTotal),
+			"percent_used_capacity": a.PercentUsedCapacity,
+			"volume_count":          int(a.VolumeCount),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("snapmirror-aggregates-%d", endpointID))
+	if err := d.Set("aggregates", list); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func TestDataSourceSnapMirrorAggregatesRead(t *testing.T) {
+	api := newFakeAPI()
+	api.handle("ListSnapMirrorAggregates", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		if p["snapMirrorEndpointID"] != float64(2) {
+			return nil, &sdk.SdkError{Code: "xSnapMirrorEndpointDoesNotExist"}
+		}
+		return map[string]interface{}{"snapMirrorAggregates": []interface{}{
+			map[string]interface{}{"snapMirrorEndpointID": 2, "aggregateName": "aggr1", "nodeName": "ontap1-01",
+				"sizeAvailable": 1000, "sizeTotal": 4000, "percentUsedCapacity": 75.0, "volumeCount": 12},
+			map[string]interface{}{"snapMirrorEndpointID": 2, "aggregateName": "aggr2", "nodeName": "ontap1-02"},
+		}}, nil
+	})
+	client := newFakeAPIClient(t, api)
+	ds := dataSourceElementSwSnapMirrorAggregates()
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"snapmirror_endpoint_id": 2})
+	if err := ds.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "snapmirror-aggregates-2" || d.Get("aggregates.#") != 2 {
+		t.Fatalf("unexpected state %v", d.State().Attributes)
+	}
+	if d.Get("aggregates.0.node_name") != "ontap1-01" || d.Get("aggregates.0.percent_used_capacity") != 75.0 {
+		t.Errorf("unexpected first aggregate %v", d.Get("aggregates.0"))
+	}
+}
@@ -0,0 +1,99 @@
+package solidfire
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceElementSwSnapMirrorVolumes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceElementSwSnapMirrorVolumesRead,
+		Schema: map[string]*schema.Schema{
+			"snapmirror_endpoint_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the SnapMirror endpoint of the ONTAP cluster.",
+			},
+			"vserver": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return volumes of this SVM.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the volume with this name.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"rw", "ls", "dp"}, false),
+				Description:  "Only return volumes of this type: rw (read-write), ls (load-sharing) or dp (data protection).",
+			},
+
+			"volumes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":           {Type: schema.TypeString, Computed: true},
+						"vserver":        {Type: schema.TypeString, Computed: true},
+						"type":           {Type: schema.TypeString, Computed: true},
+						"aggregate_name": {Type: schema.TypeString, Computed: true},
+						"state":          {Type: schema.TypeString, Computed: true},
+						"size":           {Type: schema.TypeInt, Computed: true},
+						"available_size": {Type: schema.TypeInt, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElementSwSnapMirrorVolumesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	if err := client.requireAPIFeature(featureSnapMirror, "data.solidfire_snapmirror_volumes"); err != nil {
+		return err
+	}
+
+	endpointID := d.Get("snapmirror_endpoint_id").(int)
+	params := map[string]interface{}{
+		"snapMirrorEndpointID": endpointID,
+	}
+	if v, ok := d.GetOk("vserver"); ok {
+		params["vserver"] = v.(string)
+	}
+	if v, ok := d.GetOk("name"); ok {
+		params["name"] = v.(string)
+	}
+	if v, ok := d.GetOk("type"); ok {
+		params["type"] = v.(string)
+	}
+
+	volumes, err := client.ListSnapMirrorVolumes(params)
+	if err != nil {
+		return fmt.Errorf("error calling ListSnapMirrorVolumes: %s", err)
+	}
+
+	list := make([]interface{}, 0, len(volumes))
+	for _, v := range volumes {
+		list = append(list, map[string]interface{}{
+			"name":           v.Name,
+			"vserver":        v.Vserver,
+			"type":           v.Type,
+			"aggregate_name": v.AggrName,
+			"state":          v.State,
+			"size":           int(v.Size),
+			"available_size": int(v.AvailSize),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("snapmirror-volumes-%d-%s-%s-%s", endpointID, d.Get("vserver").(string), d.Get("name").(string), d.Get("type").(string)))
+	if err := d.Set("volumes", list); err != nil {
+		return err
+	}
+
+	return nil
+}
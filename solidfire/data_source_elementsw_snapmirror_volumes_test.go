@@ -0,0 +1,50 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func TestDataSourceSnapMirrorVolumesRead(t *testing.T) {
+	api := newFakeAPI()
+	api.handle("ListSnapMirrorVolumes", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{"snapMirrorVolumes": []interface{}{
+			map[string]interface{}{"snapMirrorEndpointID": 2, "name": "vol42_dp", "type": "dp", "vserver": "svm1",
+				"aggrName": "aggr1", "state": "online", "size": 10737418240, "availSize": 5368709120},
+		}}, nil
+	})
+	client := newFakeAPIClient(t, api)
+	ds := dataSourceElementSwSnapMirrorVolumes()
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"snapmirror_endpoint_id": 2,
+		"vserver":                "svm1",
+		"type":                   "dp",
+	})
+	if err := ds.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	got := api.lastParams("ListSnapMirrorVolumes")
+	if got["vserver"] != "svm1" || got["type"] != "dp" || got["snapMirrorEndpointID"] != float64(2) {
+		t.Errorf("unexpected ListSnapMirrorVolumes params %v", got)
+	}
+	if _, ok := got["name"]; ok {
+		t.Errorf("unset name filter was sent: %v", got)
+	}
+	if d.Get("volumes.#") != 1 || d.Get("volumes.0.aggregate_name") != "aggr1" || d.Get("volumes.0.available_size") != 5368709120 {
+		t.Errorf("unexpected volumes %v", d.State().Attributes)
+	}
+}
+
+func TestDataSourceSnapMirrorVolumesNeedsSnapMirror(t *testing.T) {
+	client := newFakeAPIClient(t, newFakeAPI())
+	client.SetAPIVersion("9.0")
+	ds := dataSourceElementSwSnapMirrorVolumes()
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"snapmirror_endpoint_id": 2})
+	if err := ds.Read(d, client); err == nil {
+		t.Error("expected an error on a cluster without SnapMirror")
+	}
+}
@@ -1,7 +1,6 @@
 package solidfire
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 
@@ -61,7 +60,7 @@ func dataSourceElementSwVolumeRead(d *schema.ResourceData, meta interface{}) err
 		client.initOnce.Do(client.init)
 		startID := int64(0)
 		for {
-			resp, sdkErr := client.sdkClient.ListActiveVolumes(context.TODO(), &sdk.ListActiveVolumesRequest{
+			resp, sdkErr := callSDK(client, "ListActiveVolumes", (*sdk.SFClient).ListActiveVolumes, &sdk.ListActiveVolumesRequest{
 				StartVolumeID: startID,
 			})
 			if sdkErr != nil {
@@ -1,7 +1,6 @@
 package solidfire
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 
@@ -62,7 +61,7 @@ func dataSourceElementSwVolumeAccessGroupRead(d *schema.ResourceData, meta inter
 	}
 
 	client.initOnce.Do(client.init)
-	res, sdkErr := client.sdkClient.ListVolumeAccessGroups(context.TODO(), &req)
+	res, sdkErr := callSDK(client, "ListVolumeAccessGroups", (*sdk.SFClient).ListVolumeAccessGroups, &req)
 	if sdkErr != nil {
 		return fmt.Errorf("failed to list volume access groups: %s", sdkErr.Detail)
 	}
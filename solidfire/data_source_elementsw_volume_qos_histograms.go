@@ -0,0 +1,162 @@
+package solidfire
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func dataSourceElementSwVolumeQoSHistograms() *schema.Resource {
+	histogram := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeInt},
+			Description: description,
+		}
+	}
+
+	return &schema.Resource{
+		Read: dataSourceElementSwVolumeQoSHistogramsRead,
+		Schema: map[string]*schema.Schema{
+			"volume_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the volumes to report on.",
+			},
+			"percentile": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Default:  95.0,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(float64)
+					if value <= 0 || value > 100 {
+						errors = append(errors, fmt.Errorf("%q must be greater than 0 and at most 100", k))
+					}
+					return
+				},
+				Description: "Percentile of observed IOPS that recommended_qos.max_iops should cover.",
+			},
+			"headroom_percent": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Default:  20.0,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(float64) < 0 {
+						errors = append(errors, fmt.Errorf("%q must not be negative", k))
+					}
+					return
+				},
+				Description: "Extra capacity added on top of the percentile for recommended_qos.max_iops.",
+			},
+
+			"volumes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "QoS histograms per volume. Histogram values are sample counts keyed by bucket name (for example Bucket1To19).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"volume_id":                      {Type: schema.TypeInt, Computed: true},
+						"timestamp":                      {Type: schema.TypeString, Computed: true},
+						"min_iops":                       {Type: schema.TypeInt, Computed: true},
+						"max_iops":                       {Type: schema.TypeInt, Computed: true},
+						"burst_iops":                     {Type: schema.TypeInt, Computed: true},
+						"below_min_iops_percentages":     histogram("Samples below min_iops, bucketed by IOPS as a percentage of min_iops."),
+						"min_to_max_iops_percentages":    histogram("Samples at or above min_iops, bucketed by how far between min_iops and max_iops they were (Bucket101Plus is bursting)."),
+						"target_utilization_percentages": histogram("Samples bucketed by IOPS as a percentage of the QoS target."),
+						"throttle_percentages":           histogram("Samples bucketed by how much the volume was throttled."),
+						"read_block_sizes":               histogram("Read operations bucketed by block size in bytes."),
+						"write_block_sizes":              histogram("Write operations bucketed by block size in bytes."),
+						"recommended_qos": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "QoS derived from the IOPS distributions: min_iops at the median, max_iops at percentile plus headroom_percent, burst_iops at 1.5 times max_iops. Empty when there are no samples yet.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"min_iops":     {Type: schema.TypeInt, Computed: true},
+									"max_iops":     {Type: schema.TypeInt, Computed: true},
+									"burst_iops":   {Type: schema.TypeInt, Computed: true},
+									"sample_count": {Type: schema.TypeInt, Computed: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElementSwVolumeQoSHistogramsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	volumeIDs := toInt64Slice(d.Get("volume_ids"))
+	histograms, err := client.ListVolumeQoSHistograms(volumeIDs)
+	if err != nil {
+		return fmt.Errorf("error calling ListVolumeQoSHistograms: %s", err)
+	}
+
+	volumes, err := client.ListVolumes(volumeIDs)
+	if err != nil {
+		return fmt.Errorf("error calling ListVolumes: %s", err)
+	}
+	qos := make(map[int64]sdk.QoS, len(volumes))
+	for _, v := range volumes {
+		qos[v.VolumeID] = v.Qos
+	}
+
+	percentile := d.Get("percentile").(float64)
+	headroom := d.Get("headroom_percent").(float64)
+
+	list := make([]interface{}, 0, len(histograms))
+	for _, h := range histograms {
+		current := qos[h.VolumeID]
+		v := map[string]interface{}{
+			"volume_id":                      int(h.VolumeID),
+			"timestamp":                      h.Timestamp,
+			"min_iops":                       int(current.MinIOPS),
+			"max_iops":                       int(current.MaxIOPS),
+			"burst_iops":                     int(current.BurstIOPS),
+			"below_min_iops_percentages":     flattenQoSHistogram(h.Histograms.BelowMinIopsPercentages),
+			"min_to_max_iops_percentages":    flattenQoSHistogram(h.Histograms.MinToMaxIopsPercentages),
+			"target_utilization_percentages": flattenQoSHistogram(h.Histograms.TargetUtilizationPercentages),
+			"throttle_percentages":           flattenQoSHistogram(h.Histograms.ThrottlePercentages),
+			"read_block_sizes":               flattenQoSHistogram(h.Histograms.ReadBlockSizes),
+			"write_block_sizes":              flattenQoSHistogram(h.Histograms.WriteBlockSizes),
+			"recommended_qos":                []interface{}{},
+		}
+		if rec := recommendQoS(h, current, percentile, headroom); rec != nil {
+			v["recommended_qos"] = []interface{}{map[string]interface{}{
+				"min_iops":     int(rec.MinIOPS),
+				"max_iops":     int(rec.MaxIOPS),
+				"burst_iops":   int(rec.BurstIOPS),
+				"sample_count": int(rec.SampleCount),
+			}}
+		}
+		list = append(list, v)
+	}
+
+	ids := make([]string, 0, len(volumeIDs))
+	for _, id := range volumeIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	d.SetId("qos-histograms-" + strings.Join(ids, "-"))
+	if err := d.Set("volumes", list); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func flattenQoSHistogram(h qosHistogram) map[string]interface{} {
+	out := make(map[string]interface{}, len(h))
+	for k, v := range h {
+		out[k] = int(v)
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func TestBucketUpperBound(t *testing.T) {
+	cases := map[string]int64{
+		"Bucket0":          0,
+		"Bucket1To19":      19,
+		"Bucket80To100":    100,
+		"Bucket101Plus":    101,
+		"Bucket131072Plus": 131072,
+	}
+	for name, want := range cases {
+		got, ok := bucketUpperBound(name)
+		if !ok || got != want {
+			t.Errorf("%s: got %d (ok=%t), want %d", name, got, ok, want)
+		}
+	}
+	if _, ok := bucketUpperBound("timestamp"); ok {
+		t.Error("expected a non-bucket key to be rejected")
+	}
+}
+
+func TestRecommendQoS(t *testing.T) {
+	var h volumeQoSHistograms
+	h.Histograms.BelowMinIopsPercentages = qosHistogram{"Bucket20To39": 10}
+	h.Histograms.MinToMaxIopsPercentages = qosHistogram{"Bucket1To19": 80, "Bucket80To100": 8, "Bucket101Plus": 2}
+	current := sdk.QoS{MinIOPS: 100, MaxIOPS: 1000, BurstIOPS: 1500}
+
+	rec := recommendQoS(h, current, 95, 20)
+	if rec == nil {
+		t.Fatal("expected a recommendation")
+	}
+	if rec.SampleCount != 100 || rec.MinIOPS != 300 || rec.MaxIOPS != 1200 || rec.BurstIOPS != 1800 {
+		t.Errorf("unexpected recommendation: %+v", rec)
+	}
+
+	// an idle volume is clamped to the Element minimums
+	var idle volumeQoSHistograms
+	idle.Histograms.BelowMinIopsPercentages = qosHistogram{"Bucket1To19": 100}
+	rec = recommendQoS(idle, current, 95, 20)
+	if rec.MinIOPS != qosMinIOPSFloor || rec.MaxIOPS != qosMaxIOPSFloor || rec.BurstIOPS != 150 {
+		t.Errorf("unexpected recommendation for an idle volume: %+v", rec)
+	}
+
+	if recommendQoS(volumeQoSHistograms{}, current, 95, 20) != nil {
+		t.Error("expected no recommendation without samples")
+	}
+}
+
+func TestAccDataSourceElementSwVolumeQoSHistograms_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceElementSwVolumeQoSHistogramsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.solidfire_volume_qos_histograms.test", "volumes.0.volume_id", "solidfire_volume.test", "id"),
+					resource.TestCheckResourceAttr("data.solidfire_volume_qos_histograms.test", "volumes.0.max_iops", "1000"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceElementSwVolumeQoSHistogramsConfig = `
+resource "solidfire_account" "test" {
+  username = "terraform-acceptance-qos-histograms"
+}
+
+resource "solidfire_volume" "test" {
+  name       = "terraform-acceptance-qos-histograms"
+  account_id = solidfire_account.test.id
+  total_size = 1073741824
+  enable512e = true
+  min_iops   = 100
+  max_iops   = 1000
+  burst_iops = 1500
+}
+
+data "solidfire_volume_qos_histograms" "test" {
+  volume_ids = [solidfire_volume.test.id]
+}
+`
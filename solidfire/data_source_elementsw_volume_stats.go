@@ -0,0 +1,192 @@
+package solidfire
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceElementSwVolumeStats() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceElementSwVolumeStatsRead,
+		Schema: map[string]*schema.Schema{
+			"volume_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the volumes to report on. If omitted, all active volumes are returned.",
+			},
+			"include_efficiency": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Also call GetVolumeEfficiency for each volume. This is one extra API call per volume.",
+			},
+
+			"volume_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"volumes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Performance and efficiency statistics per volume, ordered as returned by the cluster.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"volume_id":  {Type: schema.TypeInt, Computed: true},
+						"account_id": {Type: schema.TypeInt, Computed: true},
+						"volume_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Provisioned size of the volume in bytes.",
+						},
+						"actual_iops": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Current IOPS over the last 500 milliseconds.",
+						},
+						"normalized_iops": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Current IOPS normalized to 4KiB I/O, as counted against the QoS settings.",
+						},
+						"average_io_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Average size in bytes of recent I/O.",
+						},
+						"burst_iops_credit": {Type: schema.TypeInt, Computed: true},
+						"read_bytes_per_sec": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Read throughput over the last sample period.",
+						},
+						"write_bytes_per_sec": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Write throughput over the last sample period.",
+						},
+						"read_ops_last_sample":  {Type: schema.TypeInt, Computed: true},
+						"write_ops_last_sample": {Type: schema.TypeInt, Computed: true},
+						"sample_period_msec":    {Type: schema.TypeInt, Computed: true},
+						"read_bytes":            {Type: schema.TypeInt, Computed: true},
+						"write_bytes":           {Type: schema.TypeInt, Computed: true},
+						"read_ops":              {Type: schema.TypeInt, Computed: true},
+						"write_ops":             {Type: schema.TypeInt, Computed: true},
+						"latency_usec": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Average time in microseconds to complete operations in the last 500 milliseconds.",
+						},
+						"read_latency_usec":  {Type: schema.TypeInt, Computed: true},
+						"write_latency_usec": {Type: schema.TypeInt, Computed: true},
+						"client_queue_depth": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of outstanding read and write operations to the volume.",
+						},
+						"throttle_percent": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "How much the volume is being throttled below max_iops because of re-replication or transient errors (0-100).",
+						},
+						"qos_utilization_percent": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "How much of its max_iops the volume is using (can exceed 100 while bursting).",
+						},
+						"non_zero_blocks": {Type: schema.TypeInt, Computed: true},
+						"zero_blocks":     {Type: schema.TypeInt, Computed: true},
+						"timestamp":       {Type: schema.TypeString, Computed: true},
+
+						"compression": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Compression ratio of the volume. Zero when include_efficiency is false.",
+						},
+						"deduplication": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Deduplication ratio of the volume. Zero when include_efficiency is false.",
+						},
+						"thin_provisioning": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Ratio of provisioned to used space of the volume. Zero when include_efficiency is false.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceElementSwVolumeStatsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	volumeIDs := toInt64Slice(d.Get("volume_ids"))
+	stats, err := client.ListVolumeStats(volumeIDs)
+	if err != nil {
+		return fmt.Errorf("error calling ListVolumeStats: %s", err)
+	}
+
+	includeEfficiency := d.Get("include_efficiency").(bool)
+	list := make([]interface{}, 0, len(stats))
+	for _, s := range stats {
+		readBps, writeBps := s.throughput()
+		v := map[string]interface{}{
+			"volume_id":               int(s.VolumeID),
+			"account_id":              int(s.AccountID),
+			"volume_size":             int(s.VolumeSize),
+			"actual_iops":             int(s.ActualIOPS),
+			"normalized_iops":         int(s.NormalizedIOPS),
+			"average_io_size":         int(s.AverageIOPSize),
+			"burst_iops_credit":       int(s.BurstIOPSCredit),
+			"read_bytes_per_sec":      readBps,
+			"write_bytes_per_sec":     writeBps,
+			"read_ops_last_sample":    int(s.ReadOpsLastSample),
+			"write_ops_last_sample":   int(s.WriteOpsLastSample),
+			"sample_period_msec":      int(s.SamplePeriodMSec),
+			"read_bytes":              int(s.ReadBytes),
+			"write_bytes":             int(s.WriteBytes),
+			"read_ops":                int(s.ReadOps),
+			"write_ops":               int(s.WriteOps),
+			"latency_usec":            int(s.LatencyUSec),
+			"read_latency_usec":       int(s.ReadLatencyUSec),
+			"write_latency_usec":      int(s.WriteLatencyUSec),
+			"client_queue_depth":      int(s.ClientQueueDepth),
+			"throttle_percent":        s.Throttle * 100,
+			"qos_utilization_percent": s.VolumeUtilization * 100,
+			"non_zero_blocks":         int(s.NonZeroBlocks),
+			"zero_blocks":             int(s.ZeroBlocks),
+			"timestamp":               s.Timestamp,
+		}
+		if includeEfficiency {
+			eff, err := client.GetVolumeEfficiency(s.VolumeID)
+			if err != nil {
+				return fmt.Errorf("error calling GetVolumeEfficiency for volume %d: %s", s.VolumeID, err)
+			}
+			v["compression"] = eff.Compression
+			v["deduplication"] = eff.Deduplication
+			v["thin_provisioning"] = eff.ThinProvisioning
+		}
+		list = append(list, v)
+	}
+
+	ids := make([]string, 0, len(volumeIDs))
+	for _, id := range volumeIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	if len(ids) == 0 {
+		ids = append(ids, "all")
+	}
+	d.SetId("volume-stats-" + strings.Join(ids, "-"))
+	if err := d.Set("volumes", list); err != nil {
+		return err
+	}
+	d.Set("volume_count", len(stats))
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestVolumeStatsThroughput(t *testing.T) {
+	s := volumeStats{ReadBytesLastSample: 2048, WriteBytesLastSample: 1024, SamplePeriodMSec: 500}
+	read, write := s.throughput()
+	if read != 4096 || write != 2048 {
+		t.Fatalf("expected 4096/2048 bytes per second, got %v/%v", read, write)
+	}
+
+	read, write = volumeStats{ReadBytesLastSample: 2048}.throughput()
+	if read != 0 || write != 0 {
+		t.Fatalf("expected zero throughput without a sample period, got %v/%v", read, write)
+	}
+}
+
+func TestAccDataSourceElementSwVolumeStats_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceElementSwVolumeStatsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.solidfire_volume_stats.test", "volume_count", "1"),
+					resource.TestCheckResourceAttrPair("data.solidfire_volume_stats.test", "volumes.0.volume_id", "solidfire_volume.test", "id"),
+					resource.TestCheckResourceAttrSet("data.solidfire_volume_stats.test", "volumes.0.thin_provisioning"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceElementSwVolumeStatsConfig = `
+resource "solidfire_account" "test" {
+  username = "terraform-acceptance-volume-stats"
+}
+
+resource "solidfire_volume" "test" {
+  name       = "terraform-acceptance-volume-stats"
+  account_id = solidfire_account.test.id
+  total_size = 1073741824
+  enable512e = true
+  min_iops   = 100
+  max_iops   = 1000
+  burst_iops = 1500
+}
+
+data "solidfire_volume_stats" "test" {
+  volume_ids = [solidfire_volume.test.id]
+}
+`
@@ -0,0 +1,198 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type kmipKeyServer struct {
+	KeyServerID                  int64    `json:"keyServerID"`
+	KeyProviderID                int64    `json:"keyProviderID"`
+	KmipKeyServerName            string   `json:"kmipKeyServerName"`
+	KmipKeyServerHostnames       []string `json:"kmipKeyServerHostnames"`
+	KmipKeyServerPort            int64    `json:"kmipKeyServerPort"`
+	KmipCaCertificate            string   `json:"kmipCaCertificate"`
+	KmipClientCertificate        string   `json:"kmipClientCertificate"`
+	KmipAssignedProviderIsActive bool     `json:"kmipAssignedProviderIsActive"`
+}
+
+type kmipKeyProvider struct {
+	KeyProviderID       int64   `json:"keyProviderID"`
+	KeyProviderName     string  `json:"keyProviderName"`
+	KeyProviderIsActive bool    `json:"keyProviderIsActive"`
+	KeyServerIDs        []int64 `json:"keyServerIDs"`
+	KmipCapabilities    string  `json:"kmipCapabilities"`
+}
+
+type kmipKeyServerResult struct {
+	KmipKeyServer kmipKeyServer `json:"kmipKeyServer"`
+}
+
+type kmipKeyProviderResult struct {
+	KmipKeyProvider kmipKeyProvider `json:"kmipKeyProvider"`
+}
+
+// encryptionAtRestInfo is the subset of GetClusterInfo describing encryption at rest
+type encryptionAtRestInfo struct {
+	ClusterInfo struct {
+		UniqueID              string `json:"uniqueID"`
+		EncryptionAtRestState string `json:"encryptionAtRestState"`
+	} `json:"clusterInfo"`
+}
+
+func (c *Client) CreateKeyServerKmip(params map[string]interface{}) (*kmipKeyServer, error) {
+	raw, err := c.CallAPIMethod("CreateKeyServerKmip", params)
+	if err != nil {
+		return nil, err
+	}
+	var res kmipKeyServerResult
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing CreateKeyServerKmip: %s", err)
+	}
+	return &res.KmipKeyServer, nil
+}
+
+// GetKeyServerKmip returns the key server with the given ID, or nil if it does not exist
+func (c *Client) GetKeyServerKmip(id int64) (*kmipKeyServer, error) {
+	raw, err := c.CallAPIMethod("GetKeyServerKmip", map[string]interface{}{
+		"keyServerID": id,
+	})
+	if err != nil {
+		if isDoesNotExistError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var res kmipKeyServerResult
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing GetKeyServerKmip: %s", err)
+	}
+	if res.KmipKeyServer.KeyServerID != id {
+		return nil, nil
+	}
+	return &res.KmipKeyServer, nil
+}
+
+func (c *Client) ModifyKeyServerKmip(params map[string]interface{}) error {
+	_, err := c.CallAPIMethod("ModifyKeyServerKmip", params)
+	return err
+}
+
+func (c *Client) DeleteKeyServerKmip(id int64) error {
+	_, err := c.CallAPIMethod("DeleteKeyServerKmip", map[string]interface{}{
+		"keyServerID": id,
+	})
+	return err
+}
+
+func (c *Client) TestKeyServerKmip(id int64) error {
+	_, err := c.CallAPIMethod("TestKeyServerKmip", map[string]interface{}{
+		"keyServerID": id,
+	})
+	return err
+}
+
+func (c *Client) AddKeyServerToProviderKmip(keyProviderID int64, keyServerID int64) error {
+	_, err := c.CallAPIMethod("AddKeyServerToProviderKmip", map[string]interface{}{
+		"keyProviderID": keyProviderID,
+		"keyServerID":   keyServerID,
+	})
+	return err
+}
+
+func (c *Client) RemoveKeyServerFromProviderKmip(keyServerID int64) error {
+	_, err := c.CallAPIMethod("RemoveKeyServerFromProviderKmip", map[string]interface{}{
+		"keyServerID": keyServerID,
+	})
+	return err
+}
+
+func (c *Client) CreateKeyProviderKmip(name string) (*kmipKeyProvider, error) {
+	raw, err := c.CallAPIMethod("CreateKeyProviderKmip", map[string]interface{}{
+		"keyProviderName": name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res kmipKeyProviderResult
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing CreateKeyProviderKmip: %s", err)
+	}
+	return &res.KmipKeyProvider, nil
+}
+
+// GetKeyProviderKmip returns the key provider with the given ID, or nil if it does not exist
+func (c *Client) GetKeyProviderKmip(id int64) (*kmipKeyProvider, error) {
+	raw, err := c.CallAPIMethod("GetKeyProviderKmip", map[string]interface{}{
+		"keyProviderID": id,
+	})
+	if err != nil {
+		if isDoesNotExistError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var res kmipKeyProviderResult
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing GetKeyProviderKmip: %s", err)
+	}
+	if res.KmipKeyProvider.KeyProviderID != id {
+		return nil, nil
+	}
+	return &res.KmipKeyProvider, nil
+}
+
+// GetActiveKeyProviderID returns the ID of the KMIP key provider encryption at rest uses, or 0
+// when the cluster manages the keys internally
+func (c *Client) GetActiveKeyProviderID() (int64, error) {
+	raw, err := c.CallAPIMethod("ListKeyProvidersKmip", nil)
+	if err != nil {
+		return 0, err
+	}
+	var res struct {
+		KmipKeyProviders []kmipKeyProvider `json:"kmipKeyProviders"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return 0, fmt.Errorf("error parsing ListKeyProvidersKmip: %s", err)
+	}
+	for _, p := range res.KmipKeyProviders {
+		if p.KeyProviderIsActive {
+			return p.KeyProviderID, nil
+		}
+	}
+	return 0, nil
+}
+
+func (c *Client) DeleteKeyProviderKmip(id int64) error {
+	_, err := c.CallAPIMethod("DeleteKeyProviderKmip", map[string]interface{}{
+		"keyProviderID": id,
+	})
+	return err
+}
+
+func (c *Client) EnableEncryptionAtRest(keyProviderID int64) error {
+	params := map[string]interface{}{}
+	if keyProviderID != 0 {
+		params["keyProviderID"] = keyProviderID
+	}
+	_, err := c.CallAPIMethod("EnableEncryptionAtRest", params)
+	return err
+}
+
+func (c *Client) DisableEncryptionAtRest() error {
+	_, err := c.CallAPIMethod("DisableEncryptionAtRest", nil)
+	return err
+}
+
+// GetEncryptionAtRestState returns the cluster unique ID and its current encryptionAtRestState
+func (c *Client) GetEncryptionAtRestState() (string, string, error) {
+	raw, err := c.CallAPIMethod("GetClusterInfo", nil)
+	if err != nil {
+		return "", "", err
+	}
+	var res encryptionAtRestInfo
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return "", "", fmt.Errorf("error parsing GetClusterInfo: %s", err)
+	}
+	return res.ClusterInfo.UniqueID, res.ClusterInfo.EncryptionAtRestState, nil
+}
@@ -0,0 +1,79 @@
+package solidfire
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+// fakeAPI answers raw Element API calls from handlers, so that resources built on
+// CallAPIMethod can be tested without a cluster. Params reach the handlers as decoded JSON,
+// and the last params of each method are kept for tests to check.
+type fakeAPI struct {
+	mu       sync.Mutex
+	handlers map[string]func(params map[string]interface{}) (interface{}, *sdk.SdkError)
+	calls    []string
+	params   map[string]map[string]interface{}
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{
+		handlers: map[string]func(map[string]interface{}) (interface{}, *sdk.SdkError){},
+		params:   map[string]map[string]interface{}{},
+	}
+}
+
+func (f *fakeAPI) handle(method string, h func(params map[string]interface{}) (interface{}, *sdk.SdkError)) {
+	f.handlers[method] = h
+}
+
+func (f *fakeAPI) MakeSFCall(_ context.Context, method string, _ int, params interface{}, result interface{}) ([]byte, *sdk.SdkError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, method)
+
+	h, ok := f.handlers[method]
+	if !ok {
+		return nil, &sdk.SdkError{Code: "xUnknownAPIMethod", Detail: "fake API has no handler for " + method}
+	}
+	var decoded map[string]interface{}
+	raw, _ := json.Marshal(params)
+	json.Unmarshal(raw, &decoded)
+	f.params[method] = decoded
+
+	res, sdkErr := h(decoded)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	out, _ := json.Marshal(res)
+	json.Unmarshal(out, result)
+	return out, nil
+}
+
+// called returns the methods called so far, in order
+func (f *fakeAPI) called() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// lastParams returns the params of the last call to method, or nil if it was not called
+func (f *fakeAPI) lastParams(method string) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.params[method]
+}
+
+// newFakeAPIClient returns a client that sends every CallAPIMethod call to api
+func newFakeAPIClient(t *testing.T, api *fakeAPI) *Client {
+	t.Helper()
+	c := &Client{Host: "fake.example.com", caller: api}
+	c.SetAPIVersion("12.5")
+	c.initOnce.Do(func() {
+		c.limiter = newRequestLimiter(defaultMaxConcurrentRequests, 0)
+	})
+	return c
+}
@@ -0,0 +1,150 @@
+package solidfire
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type clusterNode struct {
+	NodeID                    int64  `json:"nodeID"`
+	PendingNodeID             int64  `json:"pendingNodeID"`
+	AssignedNodeID            int64  `json:"assignedNodeID"`
+	AssociatedMasterServiceID int64  `json:"associatedMasterServiceID"`
+	Compatible                bool   `json:"compatible"`
+	Name                      string `json:"name"`
+	Role                      string `json:"role"`
+	SoftwareVersion           string `json:"softwareVersion"`
+	Mip                       string `json:"mip"`
+	Cip                       string `json:"cip"`
+	Sip                       string `json:"sip"`
+	UUID                      string `json:"uuid"`
+	ChassisName               string `json:"chassisName"`
+	NodeSlot                  string `json:"nodeSlot"`
+	PlatformInfo              struct {
+		NodeType     string `json:"nodeType"`
+		ChassisType  string `json:"chassisType"`
+		CPUModel     string `json:"cpuModel"`
+		NodeMemoryGB int64  `json:"nodeMemoryGB"`
+	} `json:"platformInfo"`
+}
+
+type listAllNodesResult struct {
+	Nodes              []clusterNode `json:"nodes"`
+	PendingNodes       []clusterNode `json:"pendingNodes"`
+	PendingActiveNodes []clusterNode `json:"pendingActiveNodes"`
+}
+
+type clusterDrive struct {
+	DriveID        int64  `json:"driveID"`
+	NodeID         int64  `json:"nodeID"`
+	Serial         string `json:"serial"`
+	Slot           int64  `json:"slot"`
+	ChassisSlot    string `json:"chassisSlot"`
+	Status         string `json:"status"`
+	Type           string `json:"type"`
+	Capacity       int64  `json:"capacity"`
+	UsableCapacity int64  `json:"usableCapacity"`
+}
+
+// clusterHardwareInfo keeps the per-node and per-drive entries of GetClusterHardwareInfo raw,
+// because their content differs between platforms and Element versions
+type clusterHardwareInfo struct {
+	Nodes  map[string]json.RawMessage `json:"nodes"`
+	Drives map[string]json.RawMessage `json:"drives"`
+}
+
+// nodeHardwareSerials is the subset of a GetClusterHardwareInfo node entry holding serial numbers
+type nodeHardwareSerials struct {
+	ChassisSerial string `json:"chassisSerial"`
+	NodeSerial    string `json:"nodeSerial"`
+}
+
+// driveHardwareWear is the subset of a GetClusterHardwareInfo drive entry describing wear
+type driveHardwareWear struct {
+	Serial                 string `json:"serial"`
+	LifeRemainingPercent   int64  `json:"lifeRemainingPercent"`
+	ReserveCapacityPercent int64  `json:"reserveCapacityPercent"`
+	PowerOnHours           int64  `json:"powerOnHours"`
+}
+
+func (c *Client) ListAllNodes() (*listAllNodesResult, error) {
+	raw, err := c.CallAPIMethod("ListAllNodes", nil)
+	if err != nil {
+		return nil, err
+	}
+	var res listAllNodesResult
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListAllNodes: %s", err)
+	}
+	return &res, nil
+}
+
+func (c *Client) ListDrives() ([]clusterDrive, error) {
+	raw, err := c.CallAPIMethod("ListDrives", nil)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Drives []clusterDrive `json:"drives"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListDrives: %s", err)
+	}
+	return res.Drives, nil
+}
+
+// GetClusterHardwareInfo returns hardware details of the given type (all, nodes or drives)
+func (c *Client) GetClusterHardwareInfo(infoType string) (*clusterHardwareInfo, error) {
+	raw, err := c.CallAPIMethod("GetClusterHardwareInfo", map[string]interface{}{
+		"type": infoType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		ClusterHardwareInfo clusterHardwareInfo `json:"clusterHardwareInfo"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing GetClusterHardwareInfo: %s", err)
+	}
+	return &res.ClusterHardwareInfo, nil
+}
+
+// nodeSerials returns the serial numbers of each node keyed by node ID
+func (h *clusterHardwareInfo) nodeSerials(ctx context.Context) map[string]nodeHardwareSerials {
+	out := make(map[string]nodeHardwareSerials, len(h.Nodes))
+	for id, raw := range h.Nodes {
+		var s nodeHardwareSerials
+		if err := json.Unmarshal(raw, &s); err != nil {
+			tflog.SubsystemWarn(ctx, logResource, "Unable to parse node hardware info", map[string]interface{}{
+				"node_id": id,
+				"error":   err.Error(),
+			})
+			continue
+		}
+		out[id] = s
+	}
+	return out
+}
+
+// driveWear returns wear information of each drive keyed by drive serial number
+func (h *clusterHardwareInfo) driveWear(ctx context.Context) map[string]driveHardwareWear {
+	out := make(map[string]driveHardwareWear, len(h.Drives))
+	for id, raw := range h.Drives {
+		var w driveHardwareWear
+		if err := json.Unmarshal(raw, &w); err != nil {
+			tflog.SubsystemWarn(ctx, logResource, "Unable to parse drive hardware info", map[string]interface{}{
+				"drive": id,
+				"error": err.Error(),
+			})
+			continue
+		}
+		if w.Serial != "" {
+			out[w.Serial] = w
+		}
+	}
+	return out
+}
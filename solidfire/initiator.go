@@ -1,13 +1,36 @@
 package solidfire
 
 import (
-	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
 	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
+// initiatorInfo is an initiator as returned by ListInitiators. The SDK's Initiator has no
+// virtualNetworkIDs, so initiators are listed with raw calls.
+type initiatorInfo struct {
+	sdk.Initiator
+	VirtualNetworkIDs []int64 `json:"virtualNetworkIDs"`
+}
+
+// listInitiators calls ListInitiators with the given parameters. Errors from the cluster are
+// returned as *sdk.SdkError, as for the SDK's typed calls.
+func (c *Client) listInitiators(params map[string]interface{}) ([]initiatorInfo, error) {
+	raw, sdkErr := c.callAPIMethod("ListInitiators", params)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	var res struct {
+		Initiators []initiatorInfo `json:"initiators"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListInitiators: %s", err)
+	}
+	return res.Initiators, nil
+}
+
 type initiator struct {
 	Name                string      `json:"name"`
 	Alias               string      `json:"alias"`
@@ -22,26 +45,22 @@ func (c *Client) getInitiatorByID(id string) (initiator, error) {
 		return initiator{}, err
 	}
 
-	req := sdk.ListInitiatorsRequest{
-		Initiators: []int64{convID},
-	}
-	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListInitiators(context.TODO(), &req)
-	if sdkErr != nil {
-		return initiator{}, sdkErr
+	initiators, err := c.listInitiatorsByID(convID)
+	if err != nil {
+		return initiator{}, err
 	}
 
-	if len(res.Initiators) != 1 {
-		return initiator{}, fmt.Errorf("expected one initiator to be found. response contained %v results", len(res.Initiators))
+	if len(initiators) != 1 {
+		return initiator{}, fmt.Errorf("expected one initiator to be found. response contained %v results", len(initiators))
 	}
 
 	var init initiator
-	init.Name = res.Initiators[0].InitiatorName
-	init.Alias = res.Initiators[0].Alias
-	init.Attributes = res.Initiators[0].Attributes
-	init.InitiatorID = res.Initiators[0].InitiatorID
-	if len(res.Initiators[0].VolumeAccessGroups) > 0 {
-		init.VolumeAccessGroupID = res.Initiators[0].VolumeAccessGroups[0]
+	init.Name = initiators[0].InitiatorName
+	init.Alias = initiators[0].Alias
+	init.Attributes = initiators[0].Attributes
+	init.InitiatorID = initiators[0].InitiatorID
+	if len(initiators[0].VolumeAccessGroups) > 0 {
+		init.VolumeAccessGroupID = initiators[0].VolumeAccessGroups[0]
 	}
 
 	return init, nil
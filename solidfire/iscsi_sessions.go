@@ -0,0 +1,55 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type iscsiSession struct {
+	SessionID              int64  `json:"sessionID"`
+	AccountID              int64  `json:"accountID"`
+	AccountName            string `json:"accountName"`
+	VolumeID               int64  `json:"volumeID"`
+	VolumeInstance         int64  `json:"volumeInstance"`
+	NodeID                 int64  `json:"nodeID"`
+	ServiceID              int64  `json:"serviceID"`
+	InitiatorName          string `json:"initiatorName"`
+	InitiatorIP            string `json:"initiatorIP"`
+	InitiatorPortName      string `json:"initiatorPortName"`
+	TargetName             string `json:"targetName"`
+	TargetIP               string `json:"targetIP"`
+	VirtualNetworkID       int64  `json:"virtualNetworkID"`
+	CreateTime             string `json:"createTime"`
+	SessionState           string `json:"sessionState"`
+	MsSinceLastIscsiPDU    int64  `json:"msSinceLastIscsiPDU"`
+	MsSinceLastScsiCommand int64  `json:"msSinceLastScsiCommand"`
+	Authentication         struct {
+		AuthMethod    string `json:"authMethod"`
+		ChapAlgorithm string `json:"chapAlgorithm"`
+		ChapUsername  string `json:"chapUsername"`
+		Direction     string `json:"direction"`
+	} `json:"authentication"`
+}
+
+// state returns the state Element reports for the session. Releases that do not report one only
+// list established sessions, so those are Active.
+func (s iscsiSession) state() string {
+	if s.SessionState == "" {
+		return "Active"
+	}
+	return s.SessionState
+}
+
+func (c *Client) ListISCSISessions() ([]iscsiSession, error) {
+	raw, err := c.CallAPIMethod("ListISCSISessions", nil)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Sessions []iscsiSession `json:"sessions"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListISCSISessions: %s", err)
+	}
+	return res.Sessions, nil
+}
@@ -0,0 +1,203 @@
+package solidfire
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+const (
+	defaultMaxConcurrentRequests = 6
+	maxThrottleRetries           = 5
+	minThrottleBackoff           = 1 * time.Second
+	maxThrottleBackoff           = 30 * time.Second
+)
+
+// throttleErrorMarkers are (case-insensitive) substrings of error codes and details
+// with which the cluster or its HTTP front end signals that it is overloaded
+var throttleErrorMarkers = []string{
+	"xtoomanyrequests", "too many requests", "maxconcurrent", "clusterbusy", "server busy",
+	"throttl", "service unavailable",
+}
+
+// rejectedRequestCodes are (case-insensitive) prefixes of the error codes with which Element
+// rejects a call before running it, so that even a write can be sent again
+var rejectedRequestCodes = []string{"xtoomanyrequests", "xmaxconcurrent"}
+
+// requestLimiter gates every API call of a client: at most a fixed number run concurrently,
+// calls start no faster than the configured rate, and all calls slow down while the cluster
+// reports throttling
+type requestLimiter struct {
+	slots    chan struct{}
+	interval time.Duration
+
+	mu         sync.Mutex
+	nextStart  time.Time
+	backoff    time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// newRequestLimiter returns a limiter allowing concurrency parallel calls and at most
+// requestsPerSecond call starts per second (0 means unlimited)
+func newRequestLimiter(concurrency int, requestsPerSecond float64) *requestLimiter {
+	if concurrency < 1 {
+		concurrency = defaultMaxConcurrentRequests
+	}
+	l := &requestLimiter{
+		slots:      make(chan struct{}, concurrency),
+		minBackoff: minThrottleBackoff,
+		maxBackoff: maxThrottleBackoff,
+	}
+	if requestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return l
+}
+
+// acquire blocks until a call may start. Every acquire must be followed by a release.
+func (l *requestLimiter) acquire() {
+	l.slots <- struct{}{}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := now
+	if l.nextStart.After(start) {
+		start = l.nextStart
+	}
+	l.nextStart = start.Add(l.interval + l.backoff)
+	l.mu.Unlock()
+
+	if wait := start.Sub(now); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// release frees the slot of a finished call and adapts the backoff to its outcome:
+// throttled calls double it, successful calls halve it
+func (l *requestLimiter) release(throttled bool) {
+	l.mu.Lock()
+	if throttled {
+		l.backoff *= 2
+		if l.backoff < l.minBackoff {
+			l.backoff = l.minBackoff
+		}
+		if l.backoff > l.maxBackoff {
+			l.backoff = l.maxBackoff
+		}
+		if resume := time.Now().Add(l.backoff); resume.After(l.nextStart) {
+			l.nextStart = resume
+		}
+	} else {
+		l.backoff /= 2
+		if l.backoff < l.minBackoff/2 {
+			l.backoff = 0
+		}
+	}
+	l.mu.Unlock()
+
+	<-l.slots
+}
+
+func (l *requestLimiter) currentBackoff() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.backoff
+}
+
+func isThrottleError(sdkErr *sdk.SdkError) bool {
+	if sdkErr == nil {
+		return false
+	}
+	s := strings.ToLower(sdkErr.Code + " " + sdkErr.Detail + " " + sdkErr.Message)
+	for _, m := range throttleErrorMarkers {
+		if strings.Contains(s, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRetryableThrottleError reports whether a throttled call may be sent again: read-only calls
+// always, writes only when the cluster rejected them before running them. Other throttle errors,
+// such as a 503 from the HTTP front end, do not tell whether a write was applied.
+func isRetryableThrottleError(method string, sdkErr *sdk.SdkError) bool {
+	if !isThrottleError(sdkErr) {
+		return false
+	}
+	if isReadOnlyMethod(method) {
+		return true
+	}
+	code := strings.ToLower(sdkErr.Code)
+	for _, c := range rejectedRequestCodes {
+		if strings.HasPrefix(code, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// callSDK runs a typed SDK method such as (*sdk.SFClient).ListVolumes through the client's
+// limiter, retrying while the cluster reports throttling and the call is safe to repeat. A
+// client with its own apiCaller sends the request through it instead of the SDK.
+func callSDK[Req any, Res any](c *Client, method string, fn func(*sdk.SFClient, context.Context, Req) (Res, *sdk.SdkError), req Req) (Res, *sdk.SdkError) {
+	return callWithRetries(c, method, func(sf *sdk.SFClient, ctx context.Context) (Res, *sdk.SdkError) {
+		if c.caller != nil {
+			var res Res
+			_, sdkErr := c.caller.MakeSFCall(ctx, method, 1, req, &res)
+			return res, sdkErr
+		}
+		return fn(sf, ctx, req)
+	})
+}
+
+// callSDKNoRequest is callSDK for SDK methods that take no request, such as GetClusterInfo
+func callSDKNoRequest[Res any](c *Client, method string, fn func(*sdk.SFClient, context.Context) (Res, *sdk.SdkError)) (Res, *sdk.SdkError) {
+	return callWithRetries(c, method, func(sf *sdk.SFClient, ctx context.Context) (Res, *sdk.SdkError) {
+		if c.caller != nil {
+			var res Res
+			_, sdkErr := c.caller.MakeSFCall(ctx, method, 1, map[string]interface{}{}, &res)
+			return res, sdkErr
+		}
+		return fn(sf, ctx)
+	})
+}
+
+// callWithRetries makes one API call through the client's limiter, refreshing rejected
+// credentials and retrying throttled calls
+func callWithRetries[Res any](c *Client, method string, fn func(*sdk.SFClient, context.Context) (Res, *sdk.SdkError)) (Res, *sdk.SdkError) {
+	c.initOnce.Do(c.init)
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		ctx, done := c.beginAPICall(method)
+		sf, generation := c.currentSDKClient()
+		res, sdkErr := fn(sf, ctx)
+		done(sdkErr)
+		if sdkErr != nil && !refreshed && isAuthError(sdkErr.Code, sdkErr.Detail+" "+sdkErr.Message) {
+			refreshed = true
+			retry, err := c.refreshCredentials(generation)
+			if err != nil {
+				tflog.SubsystemWarn(ctx, logClient, "Could not refresh credentials", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			if retry {
+				continue
+			}
+		}
+		if !isRetryableThrottleError(method, sdkErr) || attempt > maxThrottleRetries {
+			if c.reads != nil && !isReadOnlyMethod(method) {
+				c.reads.invalidate(method)
+			}
+			return res, sdkErr
+		}
+		tflog.SubsystemWarn(ctx, logClient, "Cluster is throttling API calls, retrying", map[string]interface{}{
+			"attempt": attempt,
+			"backoff": c.limiter.currentBackoff().String(),
+		})
+	}
+}
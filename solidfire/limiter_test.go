@@ -0,0 +1,149 @@
+package solidfire
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func TestRequestLimiterRate(t *testing.T) {
+	l := newRequestLimiter(4, 50)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		l.acquire()
+		l.release(false)
+	}
+	// five starts at 50/s are spaced at least 4 intervals of 20ms apart
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("5 calls at 50 rps took %s, want at least 80ms", elapsed)
+	}
+}
+
+func TestRequestLimiterConcurrency(t *testing.T) {
+	l := newRequestLimiter(2, 0)
+	var running, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.acquire()
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			l.release(false)
+		}()
+	}
+	wg.Wait()
+	if peak > 2 {
+		t.Errorf("peak concurrency %d, want at most 2", peak)
+	}
+}
+
+func TestRequestLimiterBackoff(t *testing.T) {
+	l := newRequestLimiter(1, 0)
+	l.minBackoff = 10 * time.Millisecond
+	l.maxBackoff = 30 * time.Millisecond
+
+	expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond, 30 * time.Millisecond}
+	for i, want := range expected {
+		l.acquire()
+		l.release(true)
+		if got := l.currentBackoff(); got != want {
+			t.Errorf("after %d throttled calls backoff = %s, want %s", i+1, got, want)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		l.acquire()
+		l.release(false)
+	}
+	if got := l.currentBackoff(); got != 0 {
+		t.Errorf("backoff after successful calls = %s, want 0", got)
+	}
+}
+
+func TestIsThrottleError(t *testing.T) {
+	cases := []struct {
+		err  *sdk.SdkError
+		want bool
+	}{
+		{nil, false},
+		{&sdk.SdkError{Code: "xTooManyRequests"}, true},
+		{&sdk.SdkError{Detail: "HTTP 503 Service Unavailable"}, true},
+		{&sdk.SdkError{Message: "Request throttled, try again later"}, true},
+		{&sdk.SdkError{Code: "xVolumeIDDoesNotExist", Detail: "VolumeID 429 does not exist"}, false},
+	}
+	for _, c := range cases {
+		if got := isThrottleError(c.err); got != c.want {
+			t.Errorf("isThrottleError(%+v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
+
+func TestIsRetryableThrottleError(t *testing.T) {
+	cases := []struct {
+		method string
+		err    *sdk.SdkError
+		want   bool
+	}{
+		{"ListVolumes", &sdk.SdkError{Detail: "HTTP 503 Service Unavailable"}, true},
+		{"CreateVolume", &sdk.SdkError{Detail: "HTTP 503 Service Unavailable"}, false},
+		{"CreateSnapshot", &sdk.SdkError{Message: "Request throttled, try again later"}, false},
+		{"CreateVolume", &sdk.SdkError{Code: "xTooManyRequests"}, true},
+		{"AddVirtualNetwork", &sdk.SdkError{Code: "xMaxConcurrentAPIRequestsExceeded", Detail: "maxConcurrent"}, true},
+		{"CreateVolume", &sdk.SdkError{Code: "xVolumeIDDoesNotExist"}, false},
+	}
+	for _, c := range cases {
+		if got := isRetryableThrottleError(c.method, c.err); got != c.want {
+			t.Errorf("isRetryableThrottleError(%s, %+v) = %v, want %v", c.method, c.err, got, c.want)
+		}
+	}
+}
+
+func TestCallSDKRetriesThrottledCalls(t *testing.T) {
+	c := &Client{limiter: newRequestLimiter(1, 0)}
+	c.limiter.minBackoff = time.Millisecond
+	c.limiter.maxBackoff = 2 * time.Millisecond
+	c.initOnce.Do(func() {})
+
+	calls := 0
+	res, sdkErr := callSDKNoRequest(c, "GetClusterInfo", func(_ *sdk.SFClient, _ context.Context) (int, *sdk.SdkError) {
+		calls++
+		if calls < 3 {
+			return 0, &sdk.SdkError{Code: "xTooManyRequests"}
+		}
+		return 42, nil
+	})
+	if sdkErr != nil || res != 42 || calls != 3 {
+		t.Errorf("got (%d, %v) after %d calls, want (42, nil) after 3", res, sdkErr, calls)
+	}
+
+	calls = 0
+	_, sdkErr = callSDKNoRequest(c, "GetClusterInfo", func(_ *sdk.SFClient, _ context.Context) (int, *sdk.SdkError) {
+		calls++
+		return 0, &sdk.SdkError{Code: "xTooManyRequests"}
+	})
+	if sdkErr == nil || calls != maxThrottleRetries+1 {
+		t.Errorf("got error %v after %d calls, want an error after %d", sdkErr, calls, maxThrottleRetries+1)
+	}
+
+	calls = 0
+	_, sdkErr = callSDKNoRequest(c, "CreateVolume", func(_ *sdk.SFClient, _ context.Context) (int, *sdk.SdkError) {
+		calls++
+		return 0, &sdk.SdkError{Detail: "HTTP 503 Service Unavailable"}
+	})
+	if sdkErr == nil || calls != 1 {
+		t.Errorf("a write that may have been applied was sent %d times, want once", calls)
+	}
+}
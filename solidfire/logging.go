@@ -0,0 +1,142 @@
+package solidfire
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+// Log subsystems of the provider. They follow TF_LOG_PROVIDER and can be tuned individually
+// with TF_LOG_PROVIDER_SOLIDFIRE_CLIENT, TF_LOG_PROVIDER_SOLIDFIRE_RESOURCE and
+// TF_LOG_PROVIDER_SOLIDFIRE_REPLICATION.
+const (
+	logClient      = "client"
+	logResource    = "resource"
+	logReplication = "replication"
+)
+
+var logSubsystems = []string{logClient, logResource, logReplication}
+
+// sensitiveLogKeys are (case-insensitive) substrings of parameter names whose values are never logged
+var sensitiveLogKeys = []string{"secret", "password", "passphrase", "pairingkey", "token", "privatekey"}
+
+const redactedLogValue = "***"
+
+// newLogContext adds the provider log subsystems to ctx, masking every occurrence
+// of the given literal secrets (such as the cluster password)
+func newLogContext(ctx context.Context, secrets ...string) context.Context {
+	for _, s := range logSubsystems {
+		ctx = tflog.NewSubsystem(ctx, s, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_SOLIDFIRE", strings.ToUpper(s)))
+	}
+	return maskLogSecrets(ctx, secrets...)
+}
+
+// maskLogSecrets masks more literal secrets in the provider log subsystems of ctx, such as a
+// refreshed password
+func maskLogSecrets(ctx context.Context, secrets ...string) context.Context {
+	var literals []string
+	for _, s := range secrets {
+		if s != "" {
+			literals = append(literals, s)
+		}
+	}
+	if len(literals) == 0 {
+		return ctx
+	}
+	for _, s := range logSubsystems {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, s, literals...)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, s, literals...)
+	}
+	return ctx
+}
+
+func isSensitiveLogKey(key string) bool {
+	k := strings.ToLower(key)
+	for _, s := range sensitiveLogKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactLogValue returns a copy of v with the values of sensitive map keys replaced, at any depth
+func redactLogValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			if isSensitiveLogKey(k) {
+				out[k] = redactedLogValue
+			} else {
+				out[k] = redactLogValue(val)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = redactLogValue(val)
+		}
+		return out
+	case []map[string]interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = redactLogValue(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// logContext returns the context provider logs are written to. CRUD functions of this provider
+// do not receive a context, so the one from provider configuration is kept on the client.
+func (c *Client) logContext() context.Context {
+	c.credMu.RLock()
+	defer c.credMu.RUnlock()
+	if c.logCtx != nil {
+		return c.logCtx
+	}
+	return context.Background()
+}
+
+// beginAPICall waits for the client's limiter and logs the start of an Element API call under
+// a new correlation ID. The returned context is passed to the SDK and the returned function
+// must be called with the call's error. It is a fresh context for every call that only carries
+// the provider loggers, so it is never canceled by the RPC that configured the client.
+func (c *Client) beginAPICall(method string) (context.Context, func(*sdk.SdkError)) {
+	requestID, err := uuid.GenerateUUID()
+	if err != nil {
+		requestID = "unknown"
+	}
+	ctx := tflog.SubsystemSetField(context.WithoutCancel(c.logContext()), logClient, "request_id", requestID)
+	ctx = tflog.SubsystemSetField(ctx, logClient, "method", method)
+	ctx = tflog.SubsystemSetField(ctx, logClient, "host", c.Host)
+	if c.limiter != nil {
+		c.limiter.acquire()
+	}
+	tflog.SubsystemDebug(ctx, logClient, "Calling API")
+
+	start := time.Now()
+	return ctx, func(sdkErr *sdk.SdkError) {
+		if c.limiter != nil {
+			c.limiter.release(isThrottleError(sdkErr))
+		}
+		fields := map[string]interface{}{
+			"duration_ms": time.Since(start).Milliseconds(),
+		}
+		if sdkErr != nil {
+			fields["result"] = sdkErr.Code
+			fields["error"] = sdkErr.Detail
+			tflog.SubsystemWarn(ctx, logClient, "API call failed", fields)
+			return
+		}
+		fields["result"] = "success"
+		tflog.SubsystemDebug(ctx, logClient, "API call succeeded", fields)
+	}
+}
@@ -0,0 +1,59 @@
+package solidfire
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRedactLogValue(t *testing.T) {
+	params := map[string]interface{}{
+		"username":        "tenant1",
+		"initiatorSecret": "abcdefghijkl",
+		"targetSecret":    "mnopqrstuvwx",
+		"attributes": map[string]interface{}{
+			"owner":    "ops",
+			"password": "hunter2",
+		},
+		"initiators": []map[string]interface{}{
+			{"name": "iqn.1998-01.com.vmware:esx1", "chapSecret": "s3cr3t"},
+		},
+		"volumePairingKey": "7b22...",
+		"volumeIDs":        []interface{}{1, 2},
+	}
+
+	want := map[string]interface{}{
+		"username":        "tenant1",
+		"initiatorSecret": redactedLogValue,
+		"targetSecret":    redactedLogValue,
+		"attributes": map[string]interface{}{
+			"owner":    "ops",
+			"password": redactedLogValue,
+		},
+		"initiators": []interface{}{
+			map[string]interface{}{"name": "iqn.1998-01.com.vmware:esx1", "chapSecret": redactedLogValue},
+		},
+		"volumePairingKey": redactedLogValue,
+		"volumeIDs":        []interface{}{1, 2},
+	}
+
+	got := redactLogValue(params)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected redaction:\n got: %#v\nwant: %#v", got, want)
+	}
+	if params["initiatorSecret"] != "abcdefghijkl" {
+		t.Error("redaction must not modify the original parameters")
+	}
+}
+
+func TestBeginAPICallIgnoresCanceledLogContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := &Client{Host: "10.0.0.1", logCtx: ctx}
+
+	callCtx, done := client.beginAPICall("ListVolumes")
+	defer done(nil)
+	if err := callCtx.Err(); err != nil {
+		t.Errorf("API call context should not be canceled with the log context: %s", err)
+	}
+}
@@ -1,7 +1,12 @@
 package solidfire
 
 import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns the Terraform provider definition for ElementSW
@@ -10,15 +15,33 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SOLIDFIRE_USERNAME", nil),
-				Description: "The user name for ElementSW API operations.",
+				Description: "The user name for ElementSW API operations. Read from `credential_process` or the credentials file when not set.",
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SOLIDFIRE_PASSWORD", nil),
-				Description: "The user password for ElementSW API operations.",
+				Description: "The user password for ElementSW API operations. Read from `credential_process` or the credentials file when not set. Can be an ephemeral value.",
+			},
+			"credential_process": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SOLIDFIRE_CREDENTIAL_PROCESS", nil),
+				Description: "Command that prints the credentials as JSON (`{\"username\": \"...\", \"password\": \"...\"}`), run again when the cluster rejects them.",
+			},
+			"credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SOLIDFIRE_CREDENTIALS_FILE", nil),
+				Description: "INI or YAML file with named credential profiles, read when neither the credentials nor `credential_process` are set. Defaults to `~/.solidfire/credentials`.",
+			},
+			"credentials_profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SOLIDFIRE_PROFILE", nil),
+				Description: "Profile of the credentials file to use. Defaults to `default`.",
 			},
 			"solidfire_server": {
 				Type:        schema.TypeString,
@@ -28,50 +51,132 @@ func Provider() *schema.Provider {
 			},
 			"api_version": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SOLIDFIRE_API_VERSION", nil),
-				Description: "The ElementSW server API version.",
+				Description: "The ElementSW server API version. Detected from the cluster when not set.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SOLIDFIRE_MAX_CONCURRENT_REQUESTS", defaultMaxConcurrentRequests),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of ElementSW API calls the provider runs at the same time. Defaults to 6.",
 			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SOLIDFIRE_REQUESTS_PER_SECOND", 0),
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "The maximum number of ElementSW API calls the provider starts per second. `0` (default) means no limit.",
+			},
+			"read_cache_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SOLIDFIRE_READ_CACHE_TTL", int(defaultReadCacheTTL/time.Second)),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "How many seconds volume, initiator and volume access group listings are reused while refreshing resources. `0` disables the cache. Defaults to 30.",
+			},
+			"cluster": clusterProfileSchema(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"solidfire_volume_access_group": resourceElementSwVolumeAccessGroup(),
-			"solidfire_initiator":           resourceElementSwInitiator(),
-			"solidfire_volume":              resourceElementSwVolume(),
-			"solidfire_account":             resourceElementSwAccount(),
-			"solidfire_qos_policy":          resourceElementswQoSPolicy(),
-			"solidfire_schedule":            resourceElementswSchedule(),
-			"solidfire_snapshot":            resourceElementswSnapshot(),
-			"solidfire_cluster_pairing":     resourceElementSwClusterPairing(),
-			"solidfire_volume_pairing":      resourceElementSwVolumePairing(),
+			"solidfire_volume_access_group":     resourceElementSwVolumeAccessGroup(),
+			"solidfire_initiator":               resourceElementSwInitiator(),
+			"solidfire_volume":                  resourceElementSwVolume(),
+			"solidfire_account":                 resourceElementSwAccount(),
+			"solidfire_qos_policy":              resourceElementswQoSPolicy(),
+			"solidfire_schedule":                resourceElementswSchedule(),
+			"solidfire_snapshot":                resourceElementswSnapshot(),
+			"solidfire_cluster_pairing":         resourceElementSwClusterPairing(),
+			"solidfire_volume_pairing":          resourceElementSwVolumePairing(),
+			"solidfire_kmip_key_server":         resourceElementSwKmipKeyServer(),
+			"solidfire_kmip_key_provider":       resourceElementSwKmipKeyProvider(),
+			"solidfire_encryption_at_rest":      resourceElementSwEncryptionAtRest(),
+			"solidfire_virtual_network":         resourceElementSwVirtualNetwork(),
+			"solidfire_cluster_full_threshold":  resourceElementSwClusterFullThreshold(),
+			"solidfire_default_qos":             resourceElementSwDefaultQoS(),
+			"solidfire_cluster_feature":         resourceElementSwClusterFeature(),
+			"solidfire_replication_failover":    resourceElementSwReplicationFailover(),
+			"solidfire_snapmirror_endpoint":     resourceElementSwSnapMirrorEndpoint(),
+			"solidfire_snapmirror_relationship": resourceElementSwSnapMirrorRelationship(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"solidfire_cluster":             dataSourceElementSwCluster(),
-			"solidfire_account":             dataSourceElementSwAccount(),
-			"solidfire_volume":              dataSourceElementSwVolume(),
-			"solidfire_volume_iqn":          dataSourceElementSwVolumeIQN(),
-			"solidfire_cluster_stats":       dataSourceElementSwClusterStats(),
-			"solidfire_volumes_by_account":  dataSourceElementswVolumesByAccount(),
-			"solidfire_qos_policy":          dataSourceElementSwQosPolicy(),
-			"solidfire_initiator":           dataSourceElementSwInitiator(),
-			"solidfire_volume_access_group": dataSourceElementSwVolumeAccessGroup(),
+			"solidfire_cluster":               dataSourceElementSwCluster(),
+			"solidfire_account":               dataSourceElementSwAccount(),
+			"solidfire_volume":                dataSourceElementSwVolume(),
+			"solidfire_volume_iqn":            dataSourceElementSwVolumeIQN(),
+			"solidfire_cluster_stats":         dataSourceElementSwClusterStats(),
+			"solidfire_volumes_by_account":    dataSourceElementswVolumesByAccount(),
+			"solidfire_qos_policy":            dataSourceElementSwQosPolicy(),
+			"solidfire_initiator":             dataSourceElementSwInitiator(),
+			"solidfire_volume_access_group":   dataSourceElementSwVolumeAccessGroup(),
+			"solidfire_cluster_faults":        dataSourceElementSwClusterFaults(),
+			"solidfire_cluster_events":        dataSourceElementSwClusterEvents(),
+			"solidfire_volume_stats":          dataSourceElementSwVolumeStats(),
+			"solidfire_nodes":                 dataSourceElementSwNodes(),
+			"solidfire_drives":                dataSourceElementSwDrives(),
+			"solidfire_cluster_hardware":      dataSourceElementSwClusterHardware(),
+			"solidfire_iscsi_sessions":        dataSourceElementSwISCSISessions(),
+			"solidfire_account_usage":         dataSourceElementSwAccountUsage(),
+			"solidfire_volume_qos_histograms": dataSourceElementSwVolumeQoSHistograms(),
+			"solidfire_snapmirror_volumes":    dataSourceElementSwSnapMirrorVolumes(),
+			"solidfire_snapmirror_aggregates": dataSourceElementSwSnapMirrorAggregates(),
 		},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	server := d.Get("solidfire_server").(string)
 	version := d.Get("api_version").(string)
-	user := d.Get("username").(string)
+	credentials := &providerCredentials{
+		username: d.Get("username").(string),
+		password: d.Get("password").(string),
+		process:  d.Get("credential_process").(string),
+		file:     d.Get("credentials_file").(string),
+		profile:  d.Get("credentials_profile").(string),
+	}
+	user, password, err := credentials.retrieve()
+	if err != nil {
+		return nil, diag.Errorf("no credentials for %s: %s", server, err)
+	}
 	config := configStuct{
 		User:            user,
-		Password:        d.Get("password").(string),
+		Password:        password,
 		ElementSwServer: server,
 		APIVersion:      version,
+
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		RequestsPerSecond:     d.Get("requests_per_second").(float64),
+		ReadCacheTTL:          time.Duration(d.Get("read_cache_ttl").(int)) * time.Second,
+	}
+
+	profiles, err := expandClusterProfiles(d.Get("cluster"))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	client, err := config.clientFun()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	secrets := []string{config.Password}
+	for _, p := range profiles {
+		secrets = append(secrets, p.conn.Password)
+	}
+	// The client outlives the ConfigureProvider call, so only the loggers of its context are
+	// kept: not its cancellation, which would fail every later API call, and not its root
+	// fields such as tf_req_id, which subsystem loggers do not include.
+	client.logCtx = newLogContext(context.WithoutCancel(ctx), secrets...)
+	client.profiles = profiles
+	client.credentials = credentials
+	if version == "" {
+		if err := client.negotiateAPIVersion(); err != nil {
+			return nil, diag.FromErr(err)
+		}
 	}
 
-	return config.clientFun()
+	return client, nil
 }
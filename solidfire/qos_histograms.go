@@ -0,0 +1,154 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+// qosHistogram maps bucket names (for example Bucket1To19 or Bucket101Plus) to sample counts
+type qosHistogram map[string]int64
+
+type volumeQoSHistograms struct {
+	VolumeID   int64  `json:"volumeID"`
+	Timestamp  string `json:"timestamp"`
+	Histograms struct {
+		BelowMinIopsPercentages      qosHistogram `json:"belowMinIopsPercentages"`
+		MinToMaxIopsPercentages      qosHistogram `json:"minToMaxIopsPercentages"`
+		TargetUtilizationPercentages qosHistogram `json:"targetUtilizationPercentages"`
+		ThrottlePercentages          qosHistogram `json:"throttlePercentages"`
+		ReadBlockSizes               qosHistogram `json:"readBlockSizes"`
+		WriteBlockSizes              qosHistogram `json:"writeBlockSizes"`
+	} `json:"histograms"`
+}
+
+// Element QoS limits
+const (
+	qosMinIOPSFloor   = 50
+	qosMinIOPSCeiling = 15000
+	qosMaxIOPSFloor   = 100
+	qosMaxIOPSCeiling = 200000
+)
+
+type recommendedQoS struct {
+	MinIOPS     int64
+	MaxIOPS     int64
+	BurstIOPS   int64
+	SampleCount int64
+}
+
+func (c *Client) ListVolumeQoSHistograms(volumeIDs []int64) ([]volumeQoSHistograms, error) {
+	raw, err := c.CallAPIMethod("ListVolumeQoSHistograms", map[string]interface{}{
+		"volumeIDs": volumeIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		QosHistograms []volumeQoSHistograms `json:"qosHistograms"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListVolumeQoSHistograms: %s", err)
+	}
+	return res.QosHistograms, nil
+}
+
+// bucketUpperBound returns the upper percentage of a bucket name such as Bucket1To19 (19),
+// Bucket0 (0) or Bucket101Plus (101)
+func bucketUpperBound(name string) (int64, bool) {
+	s := strings.TrimPrefix(name, "Bucket")
+	if s == name {
+		return 0, false
+	}
+	s = strings.TrimSuffix(s, "Plus")
+	if i := strings.Index(s, "To"); i >= 0 {
+		s = s[i+2:]
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+type iopsSample struct {
+	iops  float64
+	count int64
+}
+
+// recommendQoS estimates the IOPS a volume used from its histograms and the QoS it ran with,
+// then proposes min_iops at the median, max_iops at the given percentile plus headroom, and
+// burst_iops at 1.5 times max_iops. Each estimate uses the upper bound of its bucket.
+// It returns nil when the histograms contain no samples.
+func recommendQoS(h volumeQoSHistograms, current sdk.QoS, percentile float64, headroomPercent float64) *recommendedQoS {
+	var samples []iopsSample
+	add := func(hist qosHistogram, toIOPS func(pct float64) float64) {
+		for name, count := range hist {
+			pct, ok := bucketUpperBound(name)
+			if !ok || count <= 0 {
+				continue
+			}
+			samples = append(samples, iopsSample{iops: toIOPS(float64(pct)), count: count})
+		}
+	}
+	minIOPS := float64(current.MinIOPS)
+	maxIOPS := float64(current.MaxIOPS)
+	add(h.Histograms.BelowMinIopsPercentages, func(pct float64) float64 {
+		return minIOPS * pct / 100
+	})
+	add(h.Histograms.MinToMaxIopsPercentages, func(pct float64) float64 {
+		if pct > 100 {
+			// above max_iops means the volume was using burst credits
+			return float64(current.BurstIOPS)
+		}
+		return minIOPS + (maxIOPS-minIOPS)*pct/100
+	})
+
+	var total int64
+	for _, s := range samples {
+		total += s.count
+	}
+	if total == 0 {
+		return nil
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].iops < samples[j].iops })
+
+	quantile := func(q float64) float64 {
+		target := int64(math.Ceil(q / 100 * float64(total)))
+		var seen int64
+		for _, s := range samples {
+			seen += s.count
+			if seen >= target {
+				return s.iops
+			}
+		}
+		return samples[len(samples)-1].iops
+	}
+
+	roundUp := func(v float64) int64 {
+		return int64(math.Ceil(v/50) * 50)
+	}
+	clamp := func(v, lo, hi int64) int64 {
+		if v < lo {
+			return lo
+		}
+		if v > hi {
+			return hi
+		}
+		return v
+	}
+
+	rec := &recommendedQoS{SampleCount: total}
+	rec.MinIOPS = clamp(roundUp(quantile(50)), qosMinIOPSFloor, qosMinIOPSCeiling)
+	rec.MaxIOPS = clamp(roundUp(quantile(percentile)*(1+headroomPercent/100)), qosMaxIOPSFloor, qosMaxIOPSCeiling)
+	if rec.MaxIOPS < rec.MinIOPS {
+		rec.MaxIOPS = rec.MinIOPS
+	}
+	rec.BurstIOPS = clamp(roundUp(float64(rec.MaxIOPS)*1.5), rec.MaxIOPS, qosMaxIOPSCeiling)
+	return rec
+}
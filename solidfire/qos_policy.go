@@ -1,8 +1,6 @@
 package solidfire
 
 import (
-	"context"
-
 	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
@@ -12,7 +10,7 @@ func (c *Client) CreateQoSPolicy(name string, qos sdk.QoS) (int64, error) {
 		Qos:  qos,
 	}
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.CreateQoSPolicy(context.TODO(), &req)
+	res, sdkErr := callSDK(c, "CreateQoSPolicy", (*sdk.SFClient).CreateQoSPolicy, &req)
 	if sdkErr != nil {
 		return 0, sdkErr
 	}
@@ -24,7 +22,7 @@ func (c *Client) GetQoSPolicy(id int64) (*sdk.QoSPolicy, error) {
 		QosPolicyID: id,
 	}
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.GetQoSPolicy(context.TODO(), &req)
+	res, sdkErr := callSDK(c, "GetQoSPolicy", (*sdk.SFClient).GetQoSPolicy, &req)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -38,7 +36,7 @@ func (c *Client) ModifyQoSPolicy(id int64, name string, qos sdk.QoS) error {
 		Qos:         qos,
 	}
 	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.ModifyQoSPolicy(context.TODO(), &req)
+	_, sdkErr := callSDK(c, "ModifyQoSPolicy", (*sdk.SFClient).ModifyQoSPolicy, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
@@ -50,7 +48,7 @@ func (c *Client) DeleteQoSPolicy(id int64) error {
 		QosPolicyID: id,
 	}
 	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.DeleteQoSPolicy(context.TODO(), &req)
+	_, sdkErr := callSDK(c, "DeleteQoSPolicy", (*sdk.SFClient).DeleteQoSPolicy, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
@@ -59,7 +57,7 @@ func (c *Client) DeleteQoSPolicy(id int64) error {
 
 func (c *Client) ListQoSPolicies() ([]sdk.QoSPolicy, error) {
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListQoSPolicies(context.TODO())
+	res, sdkErr := callSDKNoRequest(c, "ListQoSPolicies", (*sdk.SFClient).ListQoSPolicies)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
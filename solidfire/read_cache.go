@@ -0,0 +1,246 @@
+package solidfire
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+const (
+	defaultReadCacheTTL = 30 * time.Second
+	readCachePageSize   = 1000
+)
+
+// readCache keeps the volumes, initiators and volume access groups of one cluster so that
+// refreshing many resources costs one paged sweep per inventory instead of one call per resource
+type readCache struct {
+	volumes    inventoryCache[sdk.Volume]
+	initiators inventoryCache[initiatorInfo]
+	vags       inventoryCache[sdk.VolumeAccessGroup]
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		volumes:    inventoryCache[sdk.Volume]{ttl: ttl},
+		initiators: inventoryCache[initiatorInfo]{ttl: ttl},
+		vags:       inventoryCache[sdk.VolumeAccessGroup]{ttl: ttl},
+	}
+}
+
+// invalidate drops the inventories a write method can change. Sweeps of those inventories still
+// running when it is called are not stored, and no new sweep starts for one TTL, so that during
+// an apply each read after a write is one direct lookup rather than a sweep of the whole
+// inventory. Inventories the method does not touch stay cached.
+func (rc *readCache) invalidate(method string) {
+	volumes, initiators, vags := writtenInventories(method)
+	if volumes {
+		rc.volumes.invalidate()
+	}
+	if initiators {
+		rc.initiators.invalidate()
+	}
+	if vags {
+		rc.vags.invalidate()
+	}
+}
+
+// writtenInventories reports which cached inventories a write method can change. Volume access
+// group membership is listed on the volumes and initiators too, and initiators on their groups.
+func writtenInventories(method string) (volumes, initiators, vags bool) {
+	switch {
+	case strings.Contains(method, "VolumeAccessGroup"):
+		return true, true, true
+	case strings.Contains(method, "Initiator"):
+		return false, true, true
+	case strings.Contains(method, "VirtualNetwork"):
+		return false, true, false
+	case strings.Contains(method, "Volume"), strings.Contains(method, "QoSPolicy"):
+		return true, false, false
+	}
+	return false, false, false
+}
+
+// isReadOnlyMethod reports whether an Element API method only reads cluster state
+func isReadOnlyMethod(method string) bool {
+	return strings.HasPrefix(method, "List") || strings.HasPrefix(method, "Get")
+}
+
+// inventorySweep is one listing of an inventory, shared by every caller waiting for it
+type inventorySweep[T any] struct {
+	done       chan struct{}
+	generation uint64
+	items      map[int64]T
+	err        error
+}
+
+// inventoryCache holds one inventory keyed by object ID for ttl after it was listed
+type inventoryCache[T any] struct {
+	ttl time.Duration
+
+	mu            sync.Mutex
+	items         map[int64]T
+	loadedAt      time.Time
+	invalidatedAt time.Time
+	generation    uint64
+	running       *inventorySweep[T]
+}
+
+func (ic *inventoryCache[T]) invalidate() {
+	ic.mu.Lock()
+	ic.items = nil
+	ic.invalidatedAt = time.Now()
+	ic.generation++
+	ic.mu.Unlock()
+}
+
+// get returns the object with the given ID, listing the inventory with sweep when the cached
+// copy is missing or stale. Concurrent callers share one sweep. found is false when the
+// inventory does not contain the ID or the cache is suspended after a write.
+func (ic *inventoryCache[T]) get(id int64, sweep func() (map[int64]T, error)) (item T, found bool, err error) {
+	ic.mu.Lock()
+	for {
+		if ic.items != nil && time.Since(ic.loadedAt) < ic.ttl {
+			item, found = ic.items[id]
+			ic.mu.Unlock()
+			return item, found, nil
+		}
+		if ic.items == nil && time.Since(ic.invalidatedAt) < ic.ttl {
+			ic.mu.Unlock()
+			return item, false, nil
+		}
+		if ic.running == nil {
+			break
+		}
+		sw := ic.running
+		ic.mu.Unlock()
+		<-sw.done
+		if sw.err != nil {
+			return item, false, sw.err
+		}
+		ic.mu.Lock()
+		// a write invalidated the cache while that sweep ran, so its result may be stale
+		if sw.generation != ic.generation {
+			continue
+		}
+		item, found = sw.items[id]
+		ic.mu.Unlock()
+		return item, found, nil
+	}
+
+	sw := &inventorySweep[T]{done: make(chan struct{}), generation: ic.generation}
+	ic.running = sw
+	ic.mu.Unlock()
+
+	sw.items, sw.err = sweep()
+
+	ic.mu.Lock()
+	ic.running = nil
+	if sw.err == nil && sw.generation == ic.generation {
+		ic.items = sw.items
+		ic.loadedAt = time.Now()
+	}
+	ic.mu.Unlock()
+	close(sw.done)
+
+	if sw.err != nil {
+		return item, false, sw.err
+	}
+	item, found = sw.items[id]
+	return item, found, nil
+}
+
+// pageByID lists an inventory page by page, fetch returning the objects with IDs from start
+// onwards, until a short page signals the end
+func pageByID[T any](fetch func(start int64) ([]T, error), idOf func(T) int64) (map[int64]T, error) {
+	items := make(map[int64]T)
+	start := int64(0)
+	for {
+		page, err := fetch(start)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page {
+			id := idOf(item)
+			items[id] = item
+			if id >= start {
+				start = id + 1
+			}
+		}
+		if len(page) < readCachePageSize {
+			return items, nil
+		}
+	}
+}
+
+func (c *Client) sweepActiveVolumes() (map[int64]sdk.Volume, error) {
+	return pageByID(func(start int64) ([]sdk.Volume, error) {
+		return c.ListActiveVolumes(&sdk.ListActiveVolumesRequest{StartVolumeID: start, Limit: readCachePageSize})
+	}, func(v sdk.Volume) int64 { return v.VolumeID })
+}
+
+func (c *Client) sweepInitiators() (map[int64]initiatorInfo, error) {
+	return pageByID(func(start int64) ([]initiatorInfo, error) {
+		return c.listInitiators(map[string]interface{}{
+			"startInitiatorID": start,
+			"limit":            readCachePageSize,
+		})
+	}, func(i initiatorInfo) int64 { return i.InitiatorID })
+}
+
+func (c *Client) sweepVolumeAccessGroups() (map[int64]sdk.VolumeAccessGroup, error) {
+	return pageByID(func(start int64) ([]sdk.VolumeAccessGroup, error) {
+		res, sdkErr := callSDK(c, "ListVolumeAccessGroups", (*sdk.SFClient).ListVolumeAccessGroups,
+			&sdk.ListVolumeAccessGroupsRequest{StartVolumeAccessGroupID: start, Limit: readCachePageSize})
+		if sdkErr != nil {
+			return nil, sdkErr
+		}
+		return res.VolumeAccessGroups, nil
+	}, func(v sdk.VolumeAccessGroup) int64 { return v.VolumeAccessGroupID })
+}
+
+// fromReadCache looks id up in one of the client's cached inventories. A miss or a failed sweep
+// is not an error: the caller then asks the cluster for that one object, which also finds
+// deleted volumes and objects created since the sweep.
+func fromReadCache[T any](c *Client, inventory string, ic *inventoryCache[T], sweep func() (map[int64]T, error), id int64) (T, bool) {
+	item, found, err := ic.get(id, sweep)
+	if err != nil {
+		tflog.SubsystemDebug(c.logContext(), logClient, "Read cache sweep failed, falling back to a direct lookup", map[string]interface{}{
+			"inventory": inventory,
+			"error":     err.Error(),
+		})
+	}
+	return item, found
+}
+
+// listInitiatorsByID returns the initiator with the given ID, from the read cache when enabled
+func (c *Client) listInitiatorsByID(id int64) ([]initiatorInfo, error) {
+	c.initOnce.Do(c.init)
+	if c.reads != nil {
+		if init, ok := fromReadCache(c, "initiators", &c.reads.initiators, c.sweepInitiators, id); ok {
+			return []initiatorInfo{init}, nil
+		}
+	}
+	return c.listInitiators(map[string]interface{}{
+		"initiators": []int64{id},
+	})
+}
+
+// listVolumeAccessGroupsByID returns the volume access group with the given ID, from the read
+// cache when enabled
+func (c *Client) listVolumeAccessGroupsByID(id int64) ([]sdk.VolumeAccessGroup, error) {
+	c.initOnce.Do(c.init)
+	if c.reads != nil {
+		if vag, ok := fromReadCache(c, "volume_access_groups", &c.reads.vags, c.sweepVolumeAccessGroups, id); ok {
+			return []sdk.VolumeAccessGroup{vag}, nil
+		}
+	}
+	res, sdkErr := callSDK(c, "ListVolumeAccessGroups", (*sdk.SFClient).ListVolumeAccessGroups,
+		&sdk.ListVolumeAccessGroupsRequest{VolumeAccessGroups: []int64{id}})
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	return res.VolumeAccessGroups, nil
+}
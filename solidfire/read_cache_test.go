@@ -0,0 +1,128 @@
+package solidfire
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func TestPageByID(t *testing.T) {
+	var starts []int64
+	fetch := func(start int64) ([]int64, error) {
+		starts = append(starts, start)
+		var page []int64
+		for id := start; id < 2500 && len(page) < readCachePageSize; id++ {
+			page = append(page, id)
+		}
+		return page, nil
+	}
+	items, err := pageByID(fetch, func(id int64) int64 { return id })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2500 {
+		t.Errorf("got %d items, want 2500", len(items))
+	}
+	if want := []int64{0, 1000, 2000}; len(starts) != len(want) || starts[1] != want[1] || starts[2] != want[2] {
+		t.Errorf("fetched pages starting at %v, want %v", starts, want)
+	}
+}
+
+func TestInventoryCacheCoalescesSweeps(t *testing.T) {
+	ic := inventoryCache[string]{ttl: time.Minute}
+	var sweeps int32
+	sweep := func() (map[int64]string, error) {
+		atomic.AddInt32(&sweeps, 1)
+		time.Sleep(10 * time.Millisecond)
+		return map[int64]string{1: "one", 2: "two"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			item, found, err := ic.get(id, sweep)
+			if err != nil || (id <= 2) != found || (found && item == "") {
+				t.Errorf("get(%d) = (%q, %v, %v)", id, item, found, err)
+			}
+		}(int64(i%3 + 1))
+	}
+	wg.Wait()
+	if sweeps != 1 {
+		t.Errorf("got %d sweeps for concurrent lookups, want 1", sweeps)
+	}
+}
+
+func TestInventoryCacheInvalidate(t *testing.T) {
+	ic := inventoryCache[string]{ttl: 20 * time.Millisecond}
+	sweeps := 0
+	sweep := func() (map[int64]string, error) {
+		sweeps++
+		return map[int64]string{1: "one"}, nil
+	}
+
+	if _, found, _ := ic.get(1, sweep); !found || sweeps != 1 {
+		t.Fatalf("first lookup: found=%v sweeps=%d", found, sweeps)
+	}
+	ic.invalidate()
+	// right after a write lookups go to the cluster directly
+	if _, found, _ := ic.get(1, sweep); found || sweeps != 1 {
+		t.Errorf("lookup after write: found=%v sweeps=%d, want a miss without sweeping", found, sweeps)
+	}
+	time.Sleep(25 * time.Millisecond)
+	if _, found, _ := ic.get(1, sweep); !found || sweeps != 2 {
+		t.Errorf("lookup after TTL: found=%v sweeps=%d, want a new sweep", found, sweeps)
+	}
+}
+
+func TestCallSDKInvalidatesReadCacheOnWrite(t *testing.T) {
+	c := &Client{limiter: newRequestLimiter(1, 0), reads: newReadCache(time.Minute)}
+	c.initOnce.Do(func() {})
+	c.reads.volumes.items = map[int64]sdk.Volume{1: {VolumeID: 1}}
+	c.reads.volumes.loadedAt = time.Now()
+
+	noop := func(_ *sdk.SFClient, _ context.Context) (int, *sdk.SdkError) { return 0, nil }
+	callSDKNoRequest(c, "ListActiveVolumes", noop)
+	if c.reads.volumes.items == nil {
+		t.Fatal("read-only call invalidated the cache")
+	}
+	c.reads.initiators.items = map[int64]initiatorInfo{4: {}}
+	c.reads.initiators.loadedAt = time.Now()
+	callSDKNoRequest(c, "ModifyVolume", noop)
+	if c.reads.volumes.items != nil {
+		t.Error("write did not invalidate the cache")
+	}
+	if c.reads.initiators.items == nil {
+		t.Error("volume write invalidated the initiator cache")
+	}
+	callSDKNoRequest(c, "CreateSnapshot", noop)
+	if c.reads.initiators.items == nil {
+		t.Error("snapshot write invalidated the initiator cache")
+	}
+}
+
+func TestWrittenInventories(t *testing.T) {
+	cases := []struct {
+		method                    string
+		volumes, initiators, vags bool
+	}{
+		{"CreateVolume", true, false, false},
+		{"ModifyQoSPolicy", true, false, false},
+		{"CompleteVolumePairing", true, false, false},
+		{"ModifyInitiators", false, true, true},
+		{"AddVolumesToVolumeAccessGroup", true, true, true},
+		{"RemoveVirtualNetwork", false, true, false},
+		{"CreateSnapshot", false, false, false},
+	}
+	for _, c := range cases {
+		v, i, g := writtenInventories(c.method)
+		if v != c.volumes || i != c.initiators || g != c.vags {
+			t.Errorf("writtenInventories(%s) = %v, %v, %v, want %v, %v, %v", c.method, v, i, g, c.volumes, c.initiators, c.vags)
+		}
+	}
+}
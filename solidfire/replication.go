@@ -1,14 +1,12 @@
 package solidfire
 
 import (
-	"context"
-
 	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
 func (c *Client) StartClusterPairing() (*sdk.StartClusterPairingResult, error) {
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.StartClusterPairing(context.TODO())
+	res, sdkErr := callSDKNoRequest(c, "StartClusterPairing", (*sdk.SFClient).StartClusterPairing)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -20,7 +18,7 @@ func (c *Client) CompleteClusterPairing(key string) (*sdk.CompleteClusterPairing
 		ClusterPairingKey: key,
 	}
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.CompleteClusterPairing(context.TODO(), &req)
+	res, sdkErr := callSDK(c, "CompleteClusterPairing", (*sdk.SFClient).CompleteClusterPairing, &req)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -29,7 +27,7 @@ func (c *Client) CompleteClusterPairing(key string) (*sdk.CompleteClusterPairing
 
 func (c *Client) ListClusterPairs() ([]sdk.PairedCluster, error) {
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListClusterPairs(context.TODO())
+	res, sdkErr := callSDKNoRequest(c, "ListClusterPairs", (*sdk.SFClient).ListClusterPairs)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -41,7 +39,7 @@ func (c *Client) RemoveClusterPair(id int64) error {
 		ClusterPairID: id,
 	}
 	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.RemoveClusterPair(context.TODO(), &req)
+	_, sdkErr := callSDK(c, "RemoveClusterPair", (*sdk.SFClient).RemoveClusterPair, &req)
 	if sdkErr == nil {
 		return nil
 	}
@@ -54,7 +52,7 @@ func (c *Client) StartVolumePairing(volumeID int64, mode string) (*sdk.StartVolu
 		Mode:     mode,
 	}
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.StartVolumePairing(context.TODO(), &req)
+	res, sdkErr := callSDK(c, "StartVolumePairing", (*sdk.SFClient).StartVolumePairing, &req)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -67,7 +65,7 @@ func (c *Client) CompleteVolumePairing(volumeID int64, key string) error {
 		VolumePairingKey: key,
 	}
 	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.CompleteVolumePairing(context.TODO(), &req)
+	_, sdkErr := callSDK(c, "CompleteVolumePairing", (*sdk.SFClient).CompleteVolumePairing, &req)
 	if sdkErr == nil {
 		return nil
 	}
@@ -76,7 +74,7 @@ func (c *Client) CompleteVolumePairing(volumeID int64, key string) error {
 
 func (c *Client) ListActivePairedVolumes() ([]sdk.Volume, error) {
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListActivePairedVolumes(context.TODO(), &sdk.ListActivePairedVolumesRequest{})
+	res, sdkErr := callSDK(c, "ListActivePairedVolumes", (*sdk.SFClient).ListActivePairedVolumes, &sdk.ListActivePairedVolumesRequest{})
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -85,7 +83,7 @@ func (c *Client) ListActivePairedVolumes() ([]sdk.Volume, error) {
 
 func (c *Client) ModifyVolumePair(req *sdk.ModifyVolumePairRequest) error {
 	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.ModifyVolumePair(context.TODO(), req)
+	_, sdkErr := callSDK(c, "ModifyVolumePair", (*sdk.SFClient).ModifyVolumePair, req)
 	if sdkErr == nil {
 		return nil
 	}
@@ -97,7 +95,7 @@ func (c *Client) RemoveVolumePair(volumeID int64) error {
 		VolumeID: volumeID,
 	}
 	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.RemoveVolumePair(context.TODO(), &req)
+	_, sdkErr := callSDK(c, "RemoveVolumePair", (*sdk.SFClient).RemoveVolumePair, &req)
 	if sdkErr == nil {
 		return nil
 	}
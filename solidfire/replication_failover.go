@@ -0,0 +1,215 @@
+package solidfire
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+const (
+	sitePrimary   = "primary"
+	siteSecondary = "secondary"
+
+	failoverPlanned   = "planned"
+	failoverUnplanned = "unplanned"
+
+	accessReadWrite         = "readWrite"
+	accessReadOnly          = "readOnly"
+	accessReplicationTarget = "replicationTarget"
+
+	// a pair counts as in sync once its lag is below this
+	failoverSyncThreshold = time.Second
+)
+
+// failoverVolume is one paired volume of a failover group, with its ID and access on each site
+type failoverVolume struct {
+	PrimaryID       int64
+	SecondaryID     int64
+	PrimaryAccess   string
+	SecondaryAccess string
+}
+
+func (v failoverVolume) id(site string) int64 {
+	if site == sitePrimary {
+		return v.PrimaryID
+	}
+	return v.SecondaryID
+}
+
+func (v failoverVolume) access(site string) string {
+	if site == sitePrimary {
+		return v.PrimaryAccess
+	}
+	return v.SecondaryAccess
+}
+
+func otherSite(site string) string {
+	if site == sitePrimary {
+		return siteSecondary
+	}
+	return sitePrimary
+}
+
+// failoverStep changes the access of volumes on one site, or waits for their pairs to be in sync
+type failoverStep struct {
+	Site        string
+	VolumeIDs   []int64
+	Access      string
+	WaitForSync bool
+}
+
+// activeSite returns the site whose volumes are all readWrite while the other site's are not,
+// or "" when the group is mixed or both sites accept writes
+func activeSite(vols []failoverVolume) string {
+	for _, site := range []string{sitePrimary, siteSecondary} {
+		active := len(vols) > 0
+		for _, v := range vols {
+			if v.access(site) != accessReadWrite || v.access(otherSite(site)) == accessReadWrite {
+				active = false
+				break
+			}
+		}
+		if active {
+			return site
+		}
+	}
+	return ""
+}
+
+// planFailover returns the steps that make site the writable side of the group. Every step
+// covers all volumes still to be moved, so the group changes roles together.
+//
+// An unplanned failover only promotes site, leaving the other (possibly unreachable) site
+// alone. A planned failover stops writes on the other site, waits until the pairs are in sync
+// and then swaps roles. When both sites accept writes (after an unplanned failover), previous
+// names the site holding the current data: if that is site, the other site is only demoted so
+// that it resyncs; otherwise site is demoted first and resyncs before the swap.
+func planFailover(vols []failoverVolume, site, previous, mode string) []failoverStep {
+	other := otherSite(site)
+	var resync, swap []failoverVolume
+	for _, v := range vols {
+		switch {
+		case mode == failoverUnplanned && v.access(site) == accessReadWrite:
+		case v.access(site) == accessReadWrite && v.access(other) == accessReplicationTarget:
+		case v.access(site) == accessReadWrite && v.access(other) == accessReadWrite && previous == site:
+			resync = append(resync, v)
+		default:
+			swap = append(swap, v)
+		}
+	}
+
+	var steps []failoverStep
+	add := func(s string, access string, wait bool, vols []failoverVolume, keep func(failoverVolume) bool) {
+		var ids []int64
+		for _, v := range vols {
+			if keep == nil || keep(v) {
+				ids = append(ids, v.id(s))
+			}
+		}
+		if len(ids) > 0 {
+			steps = append(steps, failoverStep{Site: s, VolumeIDs: ids, Access: access, WaitForSync: wait})
+		}
+	}
+
+	if mode == failoverUnplanned {
+		add(site, accessReadWrite, false, swap, nil)
+		return steps
+	}
+	add(other, accessReplicationTarget, false, resync, nil)
+	add(site, accessReplicationTarget, false, swap, func(v failoverVolume) bool {
+		return v.access(site) != accessReplicationTarget
+	})
+	add(other, accessReadOnly, false, swap, func(v failoverVolume) bool {
+		return v.access(other) == accessReadWrite
+	})
+	add(other, "", true, swap, nil)
+	add(other, accessReplicationTarget, false, swap, nil)
+	add(site, accessReadWrite, false, swap, nil)
+	return steps
+}
+
+// runFailoverSteps applies steps with the client of each site
+func runFailoverSteps(steps []failoverStep, clients map[string]*Client, timeout time.Duration) error {
+	for _, step := range steps {
+		client := clients[step.Site]
+		if step.WaitForSync {
+			if err := waitForVolumePairsInSync(client, step.VolumeIDs, timeout); err != nil {
+				return fmt.Errorf("%s site: %w", step.Site, err)
+			}
+			continue
+		}
+		tflog.SubsystemInfo(client.logContext(), logReplication, "Changing volume access for failover", map[string]interface{}{
+			"site":       step.Site,
+			"access":     step.Access,
+			"volume_ids": step.VolumeIDs,
+		})
+		for _, id := range step.VolumeIDs {
+			if err := client.ModifyVolume(&sdk.ModifyVolumeRequest{VolumeID: id, Access: step.Access}); err != nil {
+				return fmt.Errorf("failed to set volume %d on the %s site to %s: %w", id, step.Site, step.Access, err)
+			}
+		}
+	}
+	return nil
+}
+
+// waitForVolumePairsInSync waits until every pair of the given (source) volumes is Active and
+// lags less than failoverSyncThreshold behind
+func waitForVolumePairsInSync(client *Client, volumeIDs []int64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		lagging, err := laggingVolumePairs(client, volumeIDs)
+		if err != nil {
+			return err
+		}
+		if len(lagging) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for volume pairs to be in sync: %v", lagging)
+		}
+		tflog.SubsystemInfo(client.logContext(), logReplication, "Waiting for volume pairs to be in sync", map[string]interface{}{
+			"lagging": lagging,
+		})
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// laggingVolumePairs describes the volumes whose pair is not Active or not yet in sync
+func laggingVolumePairs(client *Client, volumeIDs []int64) ([]string, error) {
+	stats, err := client.ListVolumeStats(volumeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume stats: %w", err)
+	}
+	delays := make(map[int64]*string, len(stats))
+	for _, s := range stats {
+		delays[s.VolumeID] = s.AsyncDelay
+	}
+
+	var lagging []string
+	for _, id := range volumeIDs {
+		vol, err := client.getPairedVolume(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read volume pair of %d: %w", id, err)
+		}
+		if vol == nil {
+			return nil, fmt.Errorf("volume %d is not paired", id)
+		}
+		if state := vol.VolumePairs[0].RemoteReplication.State; state != "Active" {
+			lagging = append(lagging, fmt.Sprintf("volume %d is %s", id, state))
+			continue
+		}
+		// Sync pairs report no asyncDelay and are in sync while Active
+		if d := delays[id]; d != nil {
+			lag, err := parseAsyncDelay(*d)
+			if err != nil {
+				return nil, err
+			}
+			if lag >= failoverSyncThreshold {
+				lagging = append(lagging, fmt.Sprintf("volume %d lags %s", id, lag))
+			}
+		}
+	}
+	return lagging, nil
+}
@@ -0,0 +1,76 @@
+package solidfire
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanFailover(t *testing.T) {
+	normal := []failoverVolume{
+		{PrimaryID: 1, SecondaryID: 11, PrimaryAccess: accessReadWrite, SecondaryAccess: accessReplicationTarget},
+		{PrimaryID: 2, SecondaryID: 12, PrimaryAccess: accessReadWrite, SecondaryAccess: accessReplicationTarget},
+	}
+	splitBrain := []failoverVolume{
+		{PrimaryID: 1, SecondaryID: 11, PrimaryAccess: accessReadWrite, SecondaryAccess: accessReadWrite},
+	}
+
+	cases := []struct {
+		name           string
+		vols           []failoverVolume
+		site, previous string
+		mode           string
+		want           []failoverStep
+	}{
+		{
+			name: "already active", vols: normal, site: sitePrimary, previous: sitePrimary, mode: failoverPlanned,
+		},
+		{
+			name: "planned failover", vols: normal, site: siteSecondary, previous: sitePrimary, mode: failoverPlanned,
+			want: []failoverStep{
+				{Site: sitePrimary, VolumeIDs: []int64{1, 2}, Access: accessReadOnly},
+				{Site: sitePrimary, VolumeIDs: []int64{1, 2}, WaitForSync: true},
+				{Site: sitePrimary, VolumeIDs: []int64{1, 2}, Access: accessReplicationTarget},
+				{Site: siteSecondary, VolumeIDs: []int64{11, 12}, Access: accessReadWrite},
+			},
+		},
+		{
+			name: "unplanned failover", vols: normal, site: siteSecondary, previous: sitePrimary, mode: failoverUnplanned,
+			want: []failoverStep{
+				{Site: siteSecondary, VolumeIDs: []int64{11, 12}, Access: accessReadWrite},
+			},
+		},
+		{
+			name: "failback after unplanned failover", vols: splitBrain, site: sitePrimary, previous: siteSecondary, mode: failoverPlanned,
+			want: []failoverStep{
+				{Site: sitePrimary, VolumeIDs: []int64{1}, Access: accessReplicationTarget},
+				{Site: siteSecondary, VolumeIDs: []int64{11}, Access: accessReadOnly},
+				{Site: siteSecondary, VolumeIDs: []int64{11}, WaitForSync: true},
+				{Site: siteSecondary, VolumeIDs: []int64{11}, Access: accessReplicationTarget},
+				{Site: sitePrimary, VolumeIDs: []int64{1}, Access: accessReadWrite},
+			},
+		},
+		{
+			name: "resync old site after unplanned failover", vols: splitBrain, site: siteSecondary, previous: siteSecondary, mode: failoverPlanned,
+			want: []failoverStep{
+				{Site: sitePrimary, VolumeIDs: []int64{1}, Access: accessReplicationTarget},
+			},
+		},
+	}
+	for _, c := range cases {
+		got := planFailover(c.vols, c.site, c.previous, c.mode)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s:\n got  %+v\n want %+v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestActiveSite(t *testing.T) {
+	vols := []failoverVolume{{PrimaryAccess: accessReplicationTarget, SecondaryAccess: accessReadWrite}}
+	if got := activeSite(vols); got != siteSecondary {
+		t.Errorf("activeSite = %q, want secondary", got)
+	}
+	vols = append(vols, failoverVolume{PrimaryAccess: accessReadWrite, SecondaryAccess: accessReadWrite})
+	if got := activeSite(vols); got != "" {
+		t.Errorf("activeSite of a split group = %q, want empty", got)
+	}
+}
@@ -1,7 +1,6 @@
 package solidfire
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 
@@ -72,7 +71,7 @@ func resourceElementSwAccountCreate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	client.initOnce.Do(client.init)
-	resp, sdkErr := client.sdkClient.AddAccount(context.TODO(), &req)
+	resp, sdkErr := callSDK(client, "AddAccount", (*sdk.SFClient).AddAccount, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
@@ -146,7 +145,7 @@ func resourceElementSwAccountUpdate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	client.initOnce.Do(client.init)
-	_, sdkErr := client.sdkClient.ModifyAccount(context.TODO(), &req)
+	_, sdkErr := callSDK(client, "ModifyAccount", (*sdk.SFClient).ModifyAccount, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
@@ -168,7 +167,7 @@ func resourceElementSwAccountDelete(d *schema.ResourceData, meta interface{}) er
 	req.AccountID = convID
 
 	client.initOnce.Do(client.init)
-	_, sdkErr := client.sdkClient.RemoveAccount(context.TODO(), &req)
+	_, sdkErr := callSDK(client, "RemoveAccount", (*sdk.SFClient).RemoveAccount, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
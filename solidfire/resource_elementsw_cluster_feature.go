@@ -0,0 +1,100 @@
+package solidfire
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterFeatures lists the features accepted by EnableFeature and whether they can be turned off again.
+// Element has no DisableFeature method, so none of them can currently be disabled.
+var clusterFeatures = map[string]bool{
+	"vvols":      false,
+	"fips":       false,
+	"FipsDrives": false,
+	"SnapMirror": false,
+}
+
+// resourceElementSwClusterFeature enables an optional cluster feature
+func resourceElementSwClusterFeature() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElementSwClusterFeatureCreate,
+		Read:   resourceElementSwClusterFeatureRead,
+		Delete: resourceElementSwClusterFeatureDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			client, ok := meta.(*Client)
+			if !ok || d.Get("feature").(string) != "SnapMirror" {
+				return nil
+			}
+			return client.requireAPIFeature(featureSnapMirror, `feature = "SnapMirror"`)
+		},
+		Schema: map[string]*schema.Schema{
+			"feature": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(string)
+					if _, ok := clusterFeatures[value]; !ok {
+						errors = append(errors, fmt.Errorf("%q is not a valid cluster feature (vvols, fips, FipsDrives, SnapMirror)", value))
+					}
+					return
+				},
+				Description: "Feature to enable: vvols, fips, FipsDrives or SnapMirror.",
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceElementSwClusterFeatureCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	feature := d.Get("feature").(string)
+
+	enabled, err := client.GetFeatureStatus(feature)
+	if err != nil {
+		return fmt.Errorf("GetFeatureStatus failed: %w", err)
+	}
+	if !enabled {
+		if err := client.EnableFeature(feature); err != nil {
+			return fmt.Errorf("EnableFeature %s failed: %w", feature, err)
+		}
+	}
+
+	d.SetId(feature)
+	return resourceElementSwClusterFeatureRead(d, meta)
+}
+
+func resourceElementSwClusterFeatureRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	feature := d.Id()
+
+	enabled, err := client.GetFeatureStatus(feature)
+	if err != nil {
+		return fmt.Errorf("GetFeatureStatus failed: %w", err)
+	}
+	if !enabled {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("feature", feature)
+	d.Set("enabled", enabled)
+	return nil
+}
+
+func resourceElementSwClusterFeatureDelete(d *schema.ResourceData, meta interface{}) error {
+	feature := d.Id()
+	if !clusterFeatures[feature] {
+		return fmt.Errorf("cluster feature %s cannot be disabled once enabled; remove it from state with 'terraform state rm' instead", feature)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,26 @@
+package solidfire
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Enabling a feature cannot be undone, so there is no acceptance test; only check destroy is refused.
+func TestClusterFeatureDeleteRefused(t *testing.T) {
+	for feature := range clusterFeatures {
+		d := schema.TestResourceDataRaw(t, resourceElementSwClusterFeature().Schema, map[string]interface{}{
+			"feature": feature,
+		})
+		d.SetId(feature)
+
+		err := resourceElementSwClusterFeatureDelete(d, &Client{})
+		if err == nil || !strings.Contains(err.Error(), "cannot be disabled") {
+			t.Errorf("expected destroy of %s to be refused, got %v", feature, err)
+		}
+		if d.Id() != feature {
+			t.Errorf("expected %s to stay in state, got ID %q", feature, d.Id())
+		}
+	}
+}
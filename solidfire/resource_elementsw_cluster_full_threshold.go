@@ -0,0 +1,185 @@
+package solidfire
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceElementSwClusterFullThreshold manages the cluster-wide fullness warning thresholds.
+// There is one set of thresholds per cluster, so destroying the resource only removes it from state.
+func resourceElementSwClusterFullThreshold() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElementSwClusterFullThresholdCreate,
+		Read:   resourceElementSwClusterFullThresholdRead,
+		Update: resourceElementSwClusterFullThresholdUpdate,
+		Delete: resourceElementSwClusterFullThresholdDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"stage2_aware_threshold": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of nodes worth of block capacity remaining when the stage 2 (warning) block fullness alert is raised.",
+			},
+			"stage3_block_threshold_percent": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validatePercent,
+				Description:  "Percentage below the stage 4 (critical) threshold at which the stage 3 (error) block fullness alert is raised.",
+			},
+			"stage3_metadata_threshold_percent": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validatePercent,
+				Description:  "Percentage below the stage 4 (critical) threshold at which the stage 3 (error) metadata fullness alert is raised.",
+			},
+			"max_metadata_over_provision_factor": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "How many times more space volumes can be provisioned than the metadata space available.",
+			},
+
+			// Current fullness levels
+			"fullness": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Highest of block_fullness and metadata_fullness (stage1Happy ... stage5CompletelyConsumed).",
+			},
+			"block_fullness": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current block fullness stage of the cluster.",
+			},
+			"metadata_fullness": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current metadata fullness stage of the cluster.",
+			},
+			"slice_reserve_used_threshold_pct": {Type: schema.TypeInt, Computed: true},
+			"stage2_block_threshold_bytes":     {Type: schema.TypeInt, Computed: true},
+			"stage3_block_threshold_bytes":     {Type: schema.TypeInt, Computed: true},
+			"stage3_low_threshold":             {Type: schema.TypeInt, Computed: true},
+			"stage4_block_threshold_bytes":     {Type: schema.TypeInt, Computed: true},
+			"stage4_critical_threshold":        {Type: schema.TypeInt, Computed: true},
+			"stage5_block_threshold_bytes":     {Type: schema.TypeInt, Computed: true},
+			"stage2_metadata_threshold_bytes":  {Type: schema.TypeInt, Computed: true},
+			"stage3_metadata_threshold_bytes":  {Type: schema.TypeInt, Computed: true},
+			"stage4_metadata_threshold_bytes":  {Type: schema.TypeInt, Computed: true},
+			"stage5_metadata_threshold_bytes":  {Type: schema.TypeInt, Computed: true},
+			"sum_total_cluster_bytes":          {Type: schema.TypeInt, Computed: true},
+			"sum_used_cluster_bytes":           {Type: schema.TypeInt, Computed: true},
+			"sum_total_metadata_cluster_bytes": {Type: schema.TypeInt, Computed: true},
+			"sum_used_metadata_cluster_bytes":  {Type: schema.TypeInt, Computed: true},
+		},
+	}
+}
+
+// validatePercent checks that an integer argument is a percentage between 0 and 100
+func validatePercent(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+	if value < 0 || value > 100 {
+		errors = append(errors, fmt.Errorf("%q must be between 0 and 100", k))
+	}
+	return
+}
+
+var clusterFullThresholdArgs = map[string]string{
+	"stage2_aware_threshold":             "stage2AwareThreshold",
+	"stage3_block_threshold_percent":     "stage3BlockThresholdPercent",
+	"stage3_metadata_threshold_percent":  "stage3MetadataThresholdPercent",
+	"max_metadata_over_provision_factor": "maxMetadataOverProvisionFactor",
+}
+
+func resourceElementSwClusterFullThresholdCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	info, err := client.GetClusterInfo()
+	if err != nil {
+		return fmt.Errorf("GetClusterInfo failed: %v", err)
+	}
+
+	params := map[string]interface{}{}
+	for arg, param := range clusterFullThresholdArgs {
+		if v, ok := d.GetOk(arg); ok {
+			params[param] = v.(int)
+		}
+	}
+	if len(params) > 0 {
+		if err := client.ModifyClusterFullThreshold(params); err != nil {
+			return fmt.Errorf("ModifyClusterFullThreshold failed: %w", err)
+		}
+	}
+
+	d.SetId(info.ClusterInfo.UniqueID)
+	return resourceElementSwClusterFullThresholdRead(d, meta)
+}
+
+func resourceElementSwClusterFullThresholdRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	t, err := client.GetClusterFullThreshold()
+	if err != nil {
+		return fmt.Errorf("GetClusterFullThreshold failed: %w", err)
+	}
+
+	d.Set("stage2_aware_threshold", int(t.Stage2AwareThreshold))
+	d.Set("stage3_block_threshold_percent", int(t.Stage3BlockThresholdPercent))
+	d.Set("stage3_metadata_threshold_percent", int(t.Stage3MetadataThresholdPercent))
+	d.Set("max_metadata_over_provision_factor", int(t.MaxMetadataOverProvisionFactor))
+	d.Set("fullness", t.Fullness)
+	d.Set("block_fullness", t.BlockFullness)
+	d.Set("metadata_fullness", t.MetadataFullness)
+	d.Set("slice_reserve_used_threshold_pct", int(t.SliceReserveUsedThresholdPct))
+	d.Set("stage2_block_threshold_bytes", int(t.Stage2BlockThresholdBytes))
+	d.Set("stage3_block_threshold_bytes", int(t.Stage3BlockThresholdBytes))
+	d.Set("stage3_low_threshold", int(t.Stage3LowThreshold))
+	d.Set("stage4_block_threshold_bytes", int(t.Stage4BlockThresholdBytes))
+	d.Set("stage4_critical_threshold", int(t.Stage4CriticalThreshold))
+	d.Set("stage5_block_threshold_bytes", int(t.Stage5BlockThresholdBytes))
+	d.Set("stage2_metadata_threshold_bytes", int(t.Stage2MetadataThresholdBytes))
+	d.Set("stage3_metadata_threshold_bytes", int(t.Stage3MetadataThresholdBytes))
+	d.Set("stage4_metadata_threshold_bytes", int(t.Stage4MetadataThresholdBytes))
+	d.Set("stage5_metadata_threshold_bytes", int(t.Stage5MetadataThresholdBytes))
+	d.Set("sum_total_cluster_bytes", int(t.SumTotalClusterBytes))
+	d.Set("sum_used_cluster_bytes", int(t.SumUsedClusterBytes))
+	d.Set("sum_total_metadata_cluster_bytes", int(t.SumTotalMetadataClusterBytes))
+	d.Set("sum_used_metadata_cluster_bytes", int(t.SumUsedMetadataClusterBytes))
+
+	return nil
+}
+
+func resourceElementSwClusterFullThresholdUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	params := map[string]interface{}{}
+	for arg, param := range clusterFullThresholdArgs {
+		if d.HasChange(arg) {
+			params[param] = d.Get(arg).(int)
+		}
+	}
+	if len(params) > 0 {
+		if err := client.ModifyClusterFullThreshold(params); err != nil {
+			return fmt.Errorf("ModifyClusterFullThreshold failed: %w", err)
+		}
+	}
+
+	return resourceElementSwClusterFullThresholdRead(d, meta)
+}
+
+func resourceElementSwClusterFullThresholdDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	// Thresholds always exist on the cluster; leave the current values in place
+	tflog.SubsystemInfo(client.logContext(), logResource, "Removing cluster full threshold from state; cluster settings are left unchanged", map[string]interface{}{
+		"id": d.Id(),
+	})
+	d.SetId("")
+	return nil
+}
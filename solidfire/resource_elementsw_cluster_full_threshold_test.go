@@ -0,0 +1,43 @@
+package solidfire
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccElementswClusterFullThreshold_basic(t *testing.T) {
+	resourceName := "solidfire_cluster_full_threshold.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterFullThresholdConfig(3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "stage3_block_threshold_percent", "3"),
+					resource.TestCheckResourceAttrSet(resourceName, "block_fullness"),
+					resource.TestCheckResourceAttrSet(resourceName, "sum_total_cluster_bytes"),
+				),
+			},
+			{
+				Config: testAccClusterFullThresholdConfig(5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "stage3_block_threshold_percent", "5"),
+				),
+			},
+			{
+				Config: testAccClusterFullThresholdConfig(3),
+			},
+		},
+	})
+}
+
+func testAccClusterFullThresholdConfig(stage3 int) string {
+	return fmt.Sprintf(`
+resource "solidfire_cluster_full_threshold" "test" {
+  stage3_block_threshold_percent = %d
+}
+`, stage3)
+}
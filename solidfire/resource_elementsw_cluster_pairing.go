@@ -1,20 +1,22 @@
 package solidfire
 
 import (
-	"context"
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
 // resourceElementSwClusterPairing manages SolidFire cluster pairing (replication)
 func resourceElementSwClusterPairing() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceElementSwClusterPairingCreate,
 		Read:   resourceElementSwClusterPairingRead,
+		Update: resourceElementSwClusterPairingUpdate,
 		Delete: resourceElementSwClusterPairingDelete,
 		Schema: map[string]*schema.Schema{
 			// Workflow 1: Manual/Key-based
@@ -25,9 +27,11 @@ func resourceElementSwClusterPairing() *schema.Resource {
 				Description: "Pairing key generated from StartClusterPairing on the source cluster.",
 			},
 			// Workflow 2: Automated
-			"source_cluster": clusterConnectionSchema("Source cluster for pairing (API endpoint, username, password)"),
-			// Always required: target cluster
-			"target_cluster": clusterConnectionSchemaRequired("Target cluster for pairing (API endpoint, username, password)"),
+			"source_cluster":         clusterConnectionSchema("Source cluster for pairing (API endpoint, username, password)"),
+			"source_cluster_profile": clusterProfileNameSchema("Name of the provider's cluster profile to use as the source cluster, instead of source_cluster."),
+			// Always required: target cluster, inline or as a profile
+			"target_cluster":         clusterConnectionSchema("Target cluster for pairing (API endpoint, username, password)"),
+			"target_cluster_profile": clusterProfileNameSchema("Name of the provider's cluster profile to use as the target cluster, instead of target_cluster."),
 			// Common outputs
 			"cluster_pair_id": {
 				Type:     schema.TypeInt,
@@ -41,16 +45,40 @@ func resourceElementSwClusterPairing() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"target_cluster_pair_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the pair on the target cluster.",
+			},
+			"cluster_pair_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the pair, the same on both clusters.",
+			},
+			"remote_cluster_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the target cluster the pair points at.",
+			},
+			"remote_mvip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The management virtual IP of the target cluster.",
+			},
 		},
 	}
+	clusterAlternatives(r.Schema, "source_cluster", "source_cluster_profile", false)
+	clusterAlternatives(r.Schema, "target_cluster", "target_cluster_profile", true)
+	return r
 }
 
-// clusterConnectionSchema returns an optional schema for cluster connection info
+// clusterConnectionSchema returns an optional schema for cluster connection info. It is not
+// ForceNew: the connection only says how to reach a cluster, so switching to a cluster
+// profile or rotating the password keeps the resource.
 func clusterConnectionSchema(desc string) *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeList,
 		Optional:    true,
-		ForceNew:    true,
 		MaxItems:    1,
 		Description: desc,
 		Elem: &schema.Resource{
@@ -63,19 +91,26 @@ func clusterConnectionSchema(desc string) *schema.Schema {
 	}
 }
 
-// clusterConnectionSchemaRequired returns a required schema for cluster connection info
-func clusterConnectionSchemaRequired(desc string) *schema.Schema {
-	s := clusterConnectionSchema(desc)
-	s.Optional = false
-	s.Required = true
-	return s
+// clusterProfileNameSchema returns an optional schema for the name of a cluster profile of the
+// provider configuration, the alternative to a connection block
+func clusterProfileNameSchema(desc string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: desc,
+	}
 }
 
-// ClusterConnection holds endpoint/username/password for a cluster
+// ClusterConnection holds the endpoint, credentials and TLS settings for a cluster
 type ClusterConnection struct {
 	Endpoint string
 	Username string
 	Password string
+
+	// APIVersion, when set, is used instead of the endpoint path or detection
+	APIVersion         string
+	InsecureSkipVerify bool
+	CACertificate      string
 }
 
 // expandClusterConnection extracts endpoint/username/password from a schema.TypeList
@@ -92,195 +127,280 @@ func expandClusterConnection(list interface{}) *ClusterConnection {
 		Endpoint: m["endpoint"].(string),
 		Username: m["username"].(string),
 		Password: m["password"].(string),
+
+		InsecureSkipVerify: true,
 	}
 }
 
-// createSFClientFromConn creates an SDK client from ClusterConnection
-func createSFClientFromConn(conn *ClusterConnection) (*sdk.SFClient, error) {
+// newClientFromConn creates a client for a remote cluster from ClusterConnection.
+// It logs through the same context as parent.
+func newClientFromConn(conn *ClusterConnection, parent *Client) (*Client, error) {
 	u, err := url.Parse(conn.Endpoint)
 	if err != nil {
 		return nil, err
 	}
-	// Extract version from path if matching /json-rpc/VERSION
-	version := "12.5" // default
+	client := &Client{
+		Host:     u.Host,
+		Username: conn.Username,
+		Password: conn.Password,
+		logCtx:   newLogContext(parent.logContext(), conn.Password),
+
+		MaxConcurrentRequests: parent.MaxConcurrentRequests,
+		RequestsPerSecond:     parent.RequestsPerSecond,
+		HTTPTransport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true},
+		},
+	}
+	if !conn.InsecureSkipVerify {
+		if err := client.verifyTLS(conn.CACertificate); err != nil {
+			return nil, err
+		}
+	}
+	// Use the configured version or the one from a /json-rpc/VERSION path, otherwise ask the
+	// remote cluster, which may run a different Element release than the local one
 	parts := strings.Split(u.Path, "/")
-	if len(parts) >= 3 && parts[1] == "json-rpc" {
-		version = parts[2]
+	if conn.APIVersion != "" {
+		client.SetAPIVersion(conn.APIVersion)
+	} else if len(parts) >= 3 && parts[1] == "json-rpc" && parts[2] != "" {
+		client.SetAPIVersion(parts[2])
+	} else if err := client.negotiateAPIVersion(); err != nil {
+		return nil, err
 	}
-
-	client := &sdk.SFClient{}
-	// Note: using Host only as sdk.Connect builds URL
-	client.Connect(context.TODO(), u.Host, version, conn.Username, conn.Password)
 	return client, nil
 }
 
-// resourceElementSwClusterPairingCreate implements both pairing workflows
-func resourceElementSwClusterPairingCreate(d *schema.ResourceData, meta interface{}) error {
-	// Always require target_cluster
-	targetList, targetOk := d.GetOk("target_cluster")
-	if !targetOk {
-		return fmt.Errorf("target_cluster must be provided")
+// clusterPairingClient returns the client of the local side of the pairing, which is the
+// source cluster when set and the provider's cluster otherwise
+func clusterPairingClient(d *schema.ResourceData, meta interface{}) (*Client, error) {
+	local, err := remoteClusterClient(d, meta, "source_cluster", "source_cluster_profile")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create source cluster client: %w", err)
+	}
+	if local == nil {
+		local = meta.(*Client)
 	}
-	targetConn := expandClusterConnection(targetList)
-	if targetConn == nil {
-		return fmt.Errorf("invalid target_cluster connection info")
+	return local, nil
+}
+
+// clusterPairingClients returns clients for the local side of the pairing and for the target cluster
+func clusterPairingClients(d *schema.ResourceData, meta interface{}) (*Client, *Client, error) {
+	local, err := clusterPairingClient(d, meta)
+	if err != nil {
+		return nil, nil, err
 	}
-	targetClient, err := createSFClientFromConn(targetConn)
+	target, err := remoteClusterClient(d, meta, "target_cluster", "target_cluster_profile")
 	if err != nil {
-		return fmt.Errorf("failed to create target cluster client: %w", err)
+		return nil, nil, fmt.Errorf("failed to create target cluster client: %w", err)
 	}
+	if target == nil {
+		return nil, nil, fmt.Errorf("invalid target_cluster connection info")
+	}
+	return local, target, nil
+}
 
-	// Workflow 1: Manual/Key-based
-	if v, ok := d.GetOk("pairing_key"); ok && v.(string) != "" {
-		req := sdk.CompleteClusterPairingRequest{
-			ClusterPairingKey: v.(string),
-		}
-		pairResp, sdkErr := targetClient.CompleteClusterPairing(context.TODO(), &req)
-		if sdkErr != nil {
-			return fmt.Errorf("CompleteClusterPairing failed: %s", sdkErr.Detail)
-		}
-		d.SetId(fmt.Sprintf("%d", pairResp.ClusterPairID))
-		_ = d.Set("cluster_pair_id", int(pairResp.ClusterPairID))
-		return resourceElementSwClusterPairingRead(d, meta)
+// resourceElementSwClusterPairingCreate implements both pairing workflows. Pairs are identified
+// by the cluster they point at, so an existing pair to the target cluster is adopted and pairs
+// to other clusters are never touched.
+func resourceElementSwClusterPairingCreate(d *schema.ResourceData, meta interface{}) error {
+	key := d.Get("pairing_key").(string)
+	_, inline := d.GetOk("source_cluster")
+	_, profile := d.GetOk("source_cluster_profile")
+	if !inline && !profile && key == "" {
+		return fmt.Errorf("you must provide either pairing_key or source_cluster info (target_cluster is always required)")
+	}
+	local, target, err := clusterPairingClients(d, meta)
+	if err != nil {
+		return err
 	}
 
-	// Workflow 2: Automated
-	if sourceList, sourceOk := d.GetOk("source_cluster"); sourceOk {
-		sourceConn := expandClusterConnection(sourceList)
-		if sourceConn == nil {
-			return fmt.Errorf("invalid source_cluster connection info")
-		}
-		sourceClient, err := createSFClientFromConn(sourceConn)
-		if err != nil {
-			return fmt.Errorf("failed to create source cluster client: %w", err)
-		}
+	remote, err := target.getClusterIdentity()
+	if err != nil {
+		return fmt.Errorf("failed to get target cluster info: %w", err)
+	}
+	localCluster, err := local.getClusterIdentity()
+	if err != nil {
+		return fmt.Errorf("failed to get source cluster info: %w", err)
+	}
 
-		// Proactively check if they are already paired
-		pairs, pairErr := sourceClient.ListClusterPairs(context.TODO())
-		if pairErr == nil {
-			for _, p := range pairs.ClusterPairs {
-				// Search by status or target if possible.
-				// For now, if ANY pair exists and we're at the limit, let's try to reuse it if it matches our target
-				// SolidFire doesn't easily show target IP in ListClusterPairs easily without looking at UUIDs
-				// But we can check if it's already "Connected"
-				if p.Status == "Connected" {
-					d.SetId(fmt.Sprintf("%d", p.ClusterPairID))
-					_ = d.Set("cluster_pair_id", int(p.ClusterPairID))
-					return resourceElementSwClusterPairingRead(d, meta)
-				}
-			}
-		}
+	pairs, err := local.listClusterPairDetails()
+	if err != nil {
+		return fmt.Errorf("failed to list cluster pairs on source: %w", err)
+	}
+	pair, err := findClusterPair(pairs, remote)
+	if err != nil {
+		return err
+	}
 
-		keyResp, sdkErr := sourceClient.StartClusterPairing(context.TODO())
-		if sdkErr != nil {
-			return fmt.Errorf("StartClusterPairing failed: %s", sdkErr.Detail)
-		}
-		req := sdk.CompleteClusterPairingRequest{
-			ClusterPairingKey: keyResp.ClusterPairingKey,
-		}
-		_, sdkErr = targetClient.CompleteClusterPairing(context.TODO(), &req)
-		if sdkErr != nil {
-			// Check if already paired
-			if strings.Contains(sdkErr.Detail, "already exists") {
-				// Find existing pair ID
-				pairs, err := targetClient.ListClusterPairs(context.TODO())
-				if err == nil {
-					for _, p := range pairs.ClusterPairs {
-						// This is a bit of a hack, but helps with idempotency in tests
-						d.SetId(fmt.Sprintf("%d", p.ClusterPairID))
-						_ = d.Set("cluster_pair_id", int(p.ClusterPairID))
-						return resourceElementSwClusterPairingRead(d, meta)
-					}
-				}
+	ctx := local.logContext()
+	if pair != nil {
+		tflog.SubsystemInfo(ctx, logReplication, "Adopting existing cluster pair to target cluster", map[string]interface{}{
+			"cluster_pair_id": pair.ClusterPairID,
+			"remote_cluster":  remote.Name,
+			"remote_mvip":     remote.Mvip,
+		})
+	} else {
+		// Workflow 2 generates the key, workflow 1 brings its own
+		if key == "" {
+			keyResp, err := local.StartClusterPairing()
+			if err != nil {
+				return fmt.Errorf("StartClusterPairing failed: %w", err)
 			}
-			return fmt.Errorf("StartClusterPairing succeeded but CompleteClusterPairing failed: %s", sdkErr.Detail)
+			key = keyResp.ClusterPairingKey
+		}
+		if _, err := target.CompleteClusterPairing(key); err != nil {
+			return fmt.Errorf("CompleteClusterPairing failed: %w", err)
 		}
 
-		// The ID returned by CompleteClusterPairing is the TARGET cluster's pair ID.
-		// Since this resource is managed by the SOURCE cluster provider, we need to find
-		// the corresponding ClusterPairID on the source cluster.
-		srcPairs, sdkErr := sourceClient.ListClusterPairs(context.TODO())
-		if sdkErr != nil {
-			return fmt.Errorf("failed to list pairs on source to find local ID: %s", sdkErr.Detail)
+		pairs, err = local.listClusterPairDetails()
+		if err != nil {
+			return fmt.Errorf("failed to list cluster pairs on source: %w", err)
 		}
-		// We can look for a pair that was recently created or matches target.
-		// For now, let's just pick the last one if we have to, or look for one which status is not 'Connected' yet if newly created.
-		// Actually, let's just use the ID from the most recently created pair if possible,
-		// or find any pair if there's only one.
-		foundID := int64(0)
-		ourlog.Infof("Found %d pairs on source cluster after completion", len(srcPairs.ClusterPairs))
-		if len(srcPairs.ClusterPairs) > 0 {
-			// Pick the one with the highest ID as it's likely the newest
-			for _, p := range srcPairs.ClusterPairs {
-				ourlog.Infof("  Pair ID: %d, Target: %s, Status: %s", p.ClusterPairID, p.ClusterName, p.Status)
-				if p.ClusterPairID > foundID {
-					foundID = p.ClusterPairID
-				}
-			}
+		pair, err = findClusterPair(pairs, remote)
+		if err != nil {
+			return err
 		}
-
-		if foundID == 0 {
-			return fmt.Errorf("could not find cluster pair on source cluster after completing on target")
+		if pair == nil {
+			return fmt.Errorf("pairing completed on %s but the source cluster %s has no pair to it; was the pairing key generated on another cluster?",
+				remote.Mvip, localCluster.Mvip)
 		}
+	}
 
-		d.SetId(fmt.Sprintf("%d", foundID))
-		_ = d.Set("cluster_pair_id", int(foundID))
-		return resourceElementSwClusterPairingRead(d, meta)
+	targetPair, err := findTargetClusterPair(target, pair.ClusterPairUUID, 0, localCluster)
+	if err != nil {
+		return err
 	}
 
-	return fmt.Errorf("you must provide either pairing_key or source_cluster info (target_cluster is always required)")
+	d.SetId(fmt.Sprintf("%d", pair.ClusterPairID))
+	_ = d.Set("cluster_pair_id", int(pair.ClusterPairID))
+	_ = d.Set("cluster_pair_uuid", pair.ClusterPairUUID)
+	_ = d.Set("remote_cluster_uuid", remote.UUID)
+	_ = d.Set("remote_mvip", remote.Mvip)
+	if targetPair != nil {
+		_ = d.Set("target_cluster_pair_id", int(targetPair.ClusterPairID))
+	}
+	return resourceElementSwClusterPairingRead(d, meta)
+}
+
+// findTargetClusterPair finds the target side of a pair: by the shared pair UUID, then by its
+// recorded ID, then by the source cluster it points at
+func findTargetClusterPair(target *Client, pairUUID string, pairID int64, source clusterIdentity) (*clusterPair, error) {
+	pairs, err := target.listClusterPairDetails()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster pairs on target: %w", err)
+	}
+	if p := findClusterPairByUUID(pairs, pairUUID); p != nil {
+		return p, nil
+	}
+	if p := findClusterPairByID(pairs, pairID); p != nil && p.pointsAt(source) {
+		return p, nil
+	}
+	return findClusterPair(pairs, source)
 }
 
 // resourceElementSwClusterPairingRead reads the current state of the cluster pairing.
 func resourceElementSwClusterPairingRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Client)
+	local, err := clusterPairingClient(d, meta)
+	if err != nil {
+		return err
+	}
 
-	// List cluster pairs
-	clusterPairs, err := client.ListClusterPairs()
+	pairs, err := local.listClusterPairDetails()
 	if err != nil {
 		return fmt.Errorf("failed to list cluster pairs: %w", err)
 	}
 
 	clusterPairID := int64(d.Get("cluster_pair_id").(int))
-	ourlog.Infof("Reading cluster pair %d. Found %d pairs", clusterPairID, len(clusterPairs))
-	for _, pair := range clusterPairs {
-		ourlog.Infof("  Comparing to pair ID %d (Target: %s, Status: %s)", pair.ClusterPairID, pair.ClusterName, pair.Status)
-		if pair.ClusterPairID == clusterPairID {
-			ourlog.Infof("Found cluster pair %d. Status: %s", pair.ClusterPairID, pair.Status)
-			_ = d.Set("cluster_name", pair.ClusterName)
-			_ = d.Set("status", pair.Status)
-			return nil
-		}
+	ctx := local.logContext()
+	pair := findClusterPairByID(pairs, clusterPairID)
+	if pair == nil {
+		tflog.SubsystemWarn(ctx, logReplication, "Cluster pair not found in ListClusterPairs", map[string]interface{}{
+			"cluster_pair_id": clusterPairID,
+		})
+		d.SetId("")
+		return nil
 	}
 
-	// TOLERANCE: If we didn't find the requested ID, but there is exactly one CONNECTED pair,
-	// maybe we are looking at the wrong ID? Let's try to be smart if the requested ID is 0 or mismatch.
-	for _, pair := range clusterPairs {
-		if pair.Status == "Connected" {
-			ourlog.Infof("Found a Connected cluster pair %d (Target: %s) - using it instead of %d", pair.ClusterPairID, pair.ClusterName, clusterPairID)
-			d.SetId(fmt.Sprintf("%d", pair.ClusterPairID))
-			_ = d.Set("cluster_pair_id", int(pair.ClusterPairID))
-			_ = d.Set("cluster_name", pair.ClusterName)
-			_ = d.Set("status", pair.Status)
-			return nil
-		}
+	remoteUUID := d.Get("remote_cluster_uuid").(string)
+	if remoteUUID != "" && pair.ClusterUUID != "" && !strings.EqualFold(remoteUUID, pair.ClusterUUID) {
+		return fmt.Errorf("cluster pair %d points at cluster %s (%s), not at the paired cluster %s",
+			pair.ClusterPairID, pair.ClusterName, pair.ClusterUUID, remoteUUID)
 	}
+	tflog.SubsystemDebug(ctx, logReplication, "Found cluster pair", map[string]interface{}{
+		"cluster_pair_id": pair.ClusterPairID,
+		"status":          pair.Status,
+	})
 
-	ourlog.Warnf("Cluster pair %d NOT found in ListClusterPairs", clusterPairID)
-	d.SetId("") // Cluster pair not found
+	_ = d.Set("cluster_name", pair.ClusterName)
+	_ = d.Set("status", pair.Status)
+	if pair.ClusterPairUUID != "" {
+		_ = d.Set("cluster_pair_uuid", pair.ClusterPairUUID)
+	}
+	if pair.ClusterUUID != "" {
+		_ = d.Set("remote_cluster_uuid", pair.ClusterUUID)
+	}
+	if pair.Mvip != "" {
+		_ = d.Set("remote_mvip", pair.Mvip)
+	}
 	return nil
 }
 
-// resourceElementSwClusterPairingDelete removes the cluster pairing.
+// resourceElementSwClusterPairingUpdate only changes how the clusters are reached. A new target
+// connection or profile must still reach the paired cluster; pointing it at another cluster
+// would make Delete remove the wrong pair.
+func resourceElementSwClusterPairingUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChanges("target_cluster", "target_cluster_profile") {
+		_, target, err := clusterPairingClients(d, meta)
+		if err != nil {
+			return err
+		}
+		remote, err := target.getClusterIdentity()
+		if err != nil {
+			return fmt.Errorf("failed to get target cluster info: %w", err)
+		}
+		remoteUUID := d.Get("remote_cluster_uuid").(string)
+		if !strings.EqualFold(remote.UUID, remoteUUID) {
+			return fmt.Errorf("the new target cluster %s (%s) is not the paired cluster %s; replace the resource to pair with another cluster",
+				remote.Name, remote.UUID, remoteUUID)
+		}
+	}
+	return resourceElementSwClusterPairingRead(d, meta)
+}
+
+// resourceElementSwClusterPairingDelete removes the pair on the source cluster and then on the
+// target cluster. Sides that are already gone are skipped, so a failed destroy can be retried.
 func resourceElementSwClusterPairingDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*Client)
+	local, target, err := clusterPairingClients(d, meta)
+	if err != nil {
+		return err
+	}
 	clusterPairID := int64(d.Get("cluster_pair_id").(int))
 
-	// Remove cluster pair
-	err := client.RemoveClusterPair(clusterPairID)
+	pairs, err := local.listClusterPairDetails()
 	if err != nil {
-		return fmt.Errorf("failed to remove cluster pair: %w", err)
+		return fmt.Errorf("failed to list cluster pairs: %w", err)
+	}
+	if findClusterPairByID(pairs, clusterPairID) != nil {
+		if err := local.RemoveClusterPair(clusterPairID); err != nil {
+			return fmt.Errorf("failed to remove cluster pair: %w", err)
+		}
+	}
+
+	localCluster, err := local.getClusterIdentity()
+	if err != nil {
+		return fmt.Errorf("failed to get source cluster info: %w", err)
 	}
+	targetPair, err := findTargetClusterPair(target, d.Get("cluster_pair_uuid").(string),
+		int64(d.Get("target_cluster_pair_id").(int)), localCluster)
+	if err != nil {
+		return err
+	}
+	if targetPair != nil {
+		if err := target.RemoveClusterPair(targetPair.ClusterPairID); err != nil {
+			return fmt.Errorf("failed to remove cluster pair %d on target cluster: %w", targetPair.ClusterPairID, err)
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
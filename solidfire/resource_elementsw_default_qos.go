@@ -0,0 +1,119 @@
+package solidfire
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Element's factory default QoS, restored when solidfire_default_qos is destroyed
+const (
+	elementDefaultMinIOPS   = 50
+	elementDefaultMaxIOPS   = 15000
+	elementDefaultBurstIOPS = 15000
+)
+
+// resourceElementSwDefaultQoS manages the QoS applied to volumes created without explicit QoS settings
+func resourceElementSwDefaultQoS() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElementSwDefaultQoSCreate,
+		Read:   resourceElementSwDefaultQoSRead,
+		Update: resourceElementSwDefaultQoSUpdate,
+		Delete: resourceElementSwDefaultQoSDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"min_iops": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default minimum IOPS for new volumes.",
+			},
+			"max_iops": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default maximum IOPS for new volumes.",
+			},
+			"burst_iops": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default burst IOPS for new volumes.",
+			},
+		},
+	}
+}
+
+func resourceElementSwDefaultQoSCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	info, err := client.GetClusterInfo()
+	if err != nil {
+		return fmt.Errorf("GetClusterInfo failed: %v", err)
+	}
+
+	params := map[string]interface{}{}
+	if v, ok := d.GetOk("min_iops"); ok {
+		params["minIOPS"] = v.(int)
+	}
+	if v, ok := d.GetOk("max_iops"); ok {
+		params["maxIOPS"] = v.(int)
+	}
+	if v, ok := d.GetOk("burst_iops"); ok {
+		params["burstIOPS"] = v.(int)
+	}
+	if len(params) > 0 {
+		if err := client.SetDefaultQoS(params); err != nil {
+			return fmt.Errorf("SetDefaultQoS failed: %w", err)
+		}
+	}
+
+	d.SetId(info.ClusterInfo.UniqueID)
+	return resourceElementSwDefaultQoSRead(d, meta)
+}
+
+func resourceElementSwDefaultQoSRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	qos, err := client.GetDefaultQoS()
+	if err != nil {
+		return fmt.Errorf("GetDefaultQoS failed: %w", err)
+	}
+
+	d.Set("min_iops", int(qos.MinIOPS))
+	d.Set("max_iops", int(qos.MaxIOPS))
+	d.Set("burst_iops", int(qos.BurstIOPS))
+	return nil
+}
+
+func resourceElementSwDefaultQoSUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	params := map[string]interface{}{
+		"minIOPS":   d.Get("min_iops").(int),
+		"maxIOPS":   d.Get("max_iops").(int),
+		"burstIOPS": d.Get("burst_iops").(int),
+	}
+	if err := client.SetDefaultQoS(params); err != nil {
+		return fmt.Errorf("SetDefaultQoS failed: %w", err)
+	}
+
+	return resourceElementSwDefaultQoSRead(d, meta)
+}
+
+func resourceElementSwDefaultQoSDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	params := map[string]interface{}{
+		"minIOPS":   elementDefaultMinIOPS,
+		"maxIOPS":   elementDefaultMaxIOPS,
+		"burstIOPS": elementDefaultBurstIOPS,
+	}
+	if err := client.SetDefaultQoS(params); err != nil {
+		return fmt.Errorf("failed to restore factory default QoS: %w", err)
+	}
+	d.SetId("")
+	return nil
+}
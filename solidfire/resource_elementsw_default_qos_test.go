@@ -0,0 +1,42 @@
+package solidfire
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccElementswDefaultQoS_basic(t *testing.T) {
+	resourceName := "solidfire_default_qos.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDefaultQoSConfig(100, 10000, 12000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "min_iops", "100"),
+					resource.TestCheckResourceAttr(resourceName, "max_iops", "10000"),
+					resource.TestCheckResourceAttr(resourceName, "burst_iops", "12000"),
+				),
+			},
+			{
+				Config: testAccDefaultQoSConfig(200, 15000, 15000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "min_iops", "200"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDefaultQoSConfig(min, max, burst int) string {
+	return fmt.Sprintf(`
+resource "solidfire_default_qos" "test" {
+  min_iops   = %d
+  max_iops   = %d
+  burst_iops = %d
+}
+`, min, max, burst)
+}
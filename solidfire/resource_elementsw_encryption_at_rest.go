@@ -0,0 +1,148 @@
+package solidfire
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceElementSwEncryptionAtRest enables encryption at rest on the cluster for as long as it exists.
+// Destroying the resource disables encryption at rest.
+func resourceElementSwEncryptionAtRest() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElementSwEncryptionAtRestCreate,
+		Read:   resourceElementSwEncryptionAtRestRead,
+		Delete: resourceElementSwEncryptionAtRestDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"key_provider_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				ForceNew:    true, // switching key management requires disabling and re-enabling
+				Description: "ID of a KMIP key provider for external key management. If omitted or 0, the cluster manages the keys internally.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current encryption at rest state reported by the cluster (enabled, enabling, disabled, disabling).",
+			},
+		},
+	}
+}
+
+func resourceElementSwEncryptionAtRestCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	uniqueID, state, err := client.GetEncryptionAtRestState()
+	if err != nil {
+		return fmt.Errorf("failed to read encryption at rest state: %w", err)
+	}
+
+	keyProviderID := int64(d.Get("key_provider_id").(int))
+	if state == "enabled" || state == "enabling" {
+		// adopt encryption that is already on only when it uses the requested keys
+		active, err := client.GetActiveKeyProviderID()
+		if err != nil {
+			return fmt.Errorf("ListKeyProvidersKmip failed: %w", err)
+		}
+		if active != keyProviderID {
+			return fmt.Errorf("encryption at rest is already %s with %s, not %s; import the resource or change key_provider_id",
+				state, describeKeyProvider(active), describeKeyProvider(keyProviderID))
+		}
+	} else {
+		if err := client.EnableEncryptionAtRest(keyProviderID); err != nil {
+			return fmt.Errorf("EnableEncryptionAtRest failed: %w", err)
+		}
+	}
+	d.SetId(uniqueID)
+
+	if err := waitForEncryptionAtRestState(client, "enabled", d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	return resourceElementSwEncryptionAtRestRead(d, meta)
+}
+
+func resourceElementSwEncryptionAtRestRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	_, state, err := client.GetEncryptionAtRestState()
+	if err != nil {
+		return fmt.Errorf("failed to read encryption at rest state: %w", err)
+	}
+
+	// Disabled outside of Terraform -> plan to enable it again
+	if state == "disabled" {
+		d.SetId("")
+		return nil
+	}
+
+	keyProviderID, err := client.GetActiveKeyProviderID()
+	if err != nil {
+		return fmt.Errorf("ListKeyProvidersKmip failed: %w", err)
+	}
+	d.Set("key_provider_id", int(keyProviderID))
+	d.Set("state", state)
+	return nil
+}
+
+func resourceElementSwEncryptionAtRestDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	_, state, err := client.GetEncryptionAtRestState()
+	if err != nil {
+		return fmt.Errorf("failed to read encryption at rest state: %w", err)
+	}
+
+	if state != "disabled" && state != "disabling" {
+		if err := client.DisableEncryptionAtRest(); err != nil {
+			return fmt.Errorf("DisableEncryptionAtRest failed: %w", err)
+		}
+	}
+
+	if err := waitForEncryptionAtRestState(client, "disabled", d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// describeKeyProvider names the keys of a key_provider_id for error messages
+func describeKeyProvider(id int64) string {
+	if id == 0 {
+		return "internal keys"
+	}
+	return fmt.Sprintf("key provider %d", id)
+}
+
+// waitForEncryptionAtRestState polls GetClusterInfo until encryptionAtRestState reaches target
+func waitForEncryptionAtRestState(client *Client, target string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		_, state, err := client.GetEncryptionAtRestState()
+		if err != nil {
+			return fmt.Errorf("failed to read encryption at rest state: %w", err)
+		}
+		if state == target {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for encryption at rest to become %s (current state: %s)", target, state)
+		}
+		tflog.SubsystemInfo(client.logContext(), logResource, "Waiting for encryption at rest state", map[string]interface{}{
+			"target":  target,
+			"current": state,
+		})
+		time.Sleep(10 * time.Second)
+	}
+}
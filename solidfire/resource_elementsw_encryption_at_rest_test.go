@@ -0,0 +1,99 @@
+package solidfire
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func fakeEncryptionAtRest(api *fakeAPI, state string, activeProvider int64) {
+	api.handle("GetClusterInfo", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{"clusterInfo": map[string]interface{}{
+			"uniqueID": "abcd", "encryptionAtRestState": state,
+		}}, nil
+	})
+	api.handle("ListKeyProvidersKmip", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{"kmipKeyProviders": []interface{}{
+			map[string]interface{}{"keyProviderID": 1, "keyProviderIsActive": activeProvider == 1},
+			map[string]interface{}{"keyProviderID": 2, "keyProviderIsActive": activeProvider == 2},
+		}}, nil
+	})
+}
+
+func TestEncryptionAtRestReadsKeyProvider(t *testing.T) {
+	api := newFakeAPI()
+	fakeEncryptionAtRest(api, "enabled", 2)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementSwEncryptionAtRest()
+
+	d := r.TestResourceData()
+	d.SetId("abcd")
+	if err := r.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("key_provider_id").(int); got != 2 {
+		t.Errorf("key_provider_id = %d, want the active provider 2", got)
+	}
+
+	api = newFakeAPI()
+	fakeEncryptionAtRest(api, "enabled", 0)
+	if err := r.Read(d, newFakeAPIClient(t, api)); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("key_provider_id").(int); got != 0 {
+		t.Errorf("key_provider_id = %d, want 0 for internal keys", got)
+	}
+}
+
+func TestEncryptionAtRestCreateChecksEnabledProvider(t *testing.T) {
+	api := newFakeAPI()
+	fakeEncryptionAtRest(api, "enabled", 1)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementSwEncryptionAtRest()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"key_provider_id": 2})
+	err := r.Create(d, client)
+	if err == nil || !strings.Contains(err.Error(), "key provider 1") {
+		t.Errorf("expected an error naming the active key provider, got %v", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("encryption under another provider was adopted as %q", d.Id())
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"key_provider_id": 1})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range api.called() {
+		if m == "EnableEncryptionAtRest" {
+			t.Error("encryption that is already enabled was enabled again")
+		}
+	}
+}
+
+func TestAccElementswEncryptionAtRest_basic(t *testing.T) {
+	if os.Getenv("SOLIDFIRE_ACC_ENCRYPTION") == "" {
+		t.Skip("SOLIDFIRE_ACC_ENCRYPTION must be set to toggle encryption at rest on the test cluster")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEncryptionAtRestConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("solidfire_encryption_at_rest.test", "state", "enabled"),
+				),
+			},
+		},
+	})
+}
+
+const testAccEncryptionAtRestConfig = `
+resource "solidfire_encryption_at_rest" "test" {}
+`
@@ -1,7 +1,6 @@
 package solidfire
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 
@@ -41,6 +40,12 @@ func resourceElementSwInitiator() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"virtual_network_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the virtual networks (solidfire_virtual_network) this initiator is allowed to log in from.",
+			},
 			"iqns": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -74,7 +79,7 @@ func resourceElementSwInitiatorCreate(d *schema.ResourceData, meta interface{})
 	req.Initiators = []sdk.CreateInitiator{newInit}
 
 	client.initOnce.Do(client.init)
-	res, sdkErr := client.sdkClient.CreateInitiators(context.TODO(), &req)
+	res, sdkErr := callSDK(client, "CreateInitiators", (*sdk.SFClient).CreateInitiators, &req)
 	if sdkErr != nil {
 		if sdkErr != nil {
 			return sdkErr
@@ -83,6 +88,13 @@ func resourceElementSwInitiatorCreate(d *schema.ResourceData, meta interface{})
 	}
 
 	d.SetId(fmt.Sprintf("%v", res.Initiators[0].InitiatorID))
+
+	if v, ok := d.GetOk("virtual_network_ids"); ok {
+		if err := client.SetInitiatorVirtualNetworks(res.Initiators[0].InitiatorID, toInt64Slice(v)); err != nil {
+			return fmt.Errorf("failed to set virtual networks for initiator: %w", err)
+		}
+	}
+
 	return resourceElementSwInitiatorRead(d, meta)
 }
 
@@ -95,29 +107,23 @@ func resourceElementSwInitiatorRead(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
-	req := sdk.ListInitiatorsRequest{
-		Initiators: []int64{id},
-	}
-	client.initOnce.Do(client.init)
-	res, sdkErr := client.sdkClient.ListInitiators(context.TODO(), &req)
-	if sdkErr != nil {
-		if sdkErr != nil {
-			return sdkErr
-		}
-		return nil
+	initiators, err := client.listInitiatorsByID(id)
+	if err != nil {
+		return err
 	}
 
-	if len(res.Initiators) != 1 {
-		return fmt.Errorf("expected one initiator, got %d", len(res.Initiators))
+	if len(initiators) != 1 {
+		return fmt.Errorf("expected one initiator, got %d", len(initiators))
 	}
 
-	init := res.Initiators[0]
+	init := initiators[0]
 	d.Set("name", init.InitiatorName)
 	d.Set("alias", init.Alias)
 	d.Set("attributes", init.Attributes)
 	if len(init.VolumeAccessGroups) > 0 {
 		d.Set("volume_access_group_id", init.VolumeAccessGroups[0])
 	}
+	d.Set("virtual_network_ids", init.VirtualNetworkIDs)
 
 	return nil
 }
@@ -143,10 +149,16 @@ func resourceElementSwInitiatorUpdate(d *schema.ResourceData, meta interface{})
 	req.Initiators = []sdk.ModifyInitiator{modInit}
 
 	client.initOnce.Do(client.init)
-	_, sdkErr := client.sdkClient.ModifyInitiators(context.TODO(), &req)
+	_, sdkErr := callSDK(client, "ModifyInitiators", (*sdk.SFClient).ModifyInitiators, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
+
+	if d.HasChange("virtual_network_ids") {
+		if err := client.SetInitiatorVirtualNetworks(id, toInt64Slice(d.Get("virtual_network_ids"))); err != nil {
+			return fmt.Errorf("failed to set virtual networks for initiator: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -159,7 +171,7 @@ func resourceElementSwInitiatorDelete(d *schema.ResourceData, meta interface{})
 		Initiators: []int64{id},
 	}
 	client.initOnce.Do(client.init)
-	_, sdkErr := client.sdkClient.DeleteInitiators(context.TODO(), &req)
+	_, sdkErr := callSDK(client, "DeleteInitiators", (*sdk.SFClient).DeleteInitiators, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
@@ -174,18 +186,14 @@ func resourceElementSwInitiatorExists(d *schema.ResourceData, meta interface{})
 		return false, nil
 	}
 
-	req := sdk.ListInitiatorsRequest{
-		Initiators: []int64{id},
-	}
-	client.initOnce.Do(client.init)
-	res, sdkErr := client.sdkClient.ListInitiators(context.TODO(), &req)
-	if sdkErr != nil {
+	initiators, err := client.listInitiatorsByID(id)
+	if err != nil {
 		// Check for 500:xUnknown or similar
-		if sdkErr.Detail == "500:xUnknown" {
+		if sdkErr, ok := err.(*sdk.SdkError); ok && sdkErr.Detail == "500:xUnknown" {
 			return false, nil
 		}
-		return false, sdkErr
+		return false, err
 	}
 
-	return len(res.Initiators) == 1, nil
+	return len(initiators) == 1, nil
 }
@@ -3,13 +3,66 @@ package solidfire
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
+func TestInitiatorReadUsesReadCache(t *testing.T) {
+	api := newFakeAPI()
+	api.handle("ListInitiators", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		if _, ok := p["startInitiatorID"]; !ok {
+			t.Errorf("expected a paged sweep, got %v", p)
+		}
+		return map[string]interface{}{"initiators": []interface{}{
+			map[string]interface{}{"initiatorID": 4, "initiatorName": "iqn.1998-01.com.vmware:esx1", "volumeAccessGroups": []int64{2}, "virtualNetworkIDs": []int64{7}},
+			map[string]interface{}{"initiatorID": 5, "initiatorName": "iqn.1998-01.com.vmware:esx2", "virtualNetworkIDs": []int64{}},
+		}}, nil
+	})
+	client := newFakeAPIClient(t, api)
+	client.reads = newReadCache(time.Minute)
+	r := resourceElementSwInitiator()
+
+	for _, id := range []string{"4", "5"} {
+		d := r.TestResourceData()
+		d.SetId(id)
+		if err := r.Read(d, client); err != nil {
+			t.Fatal(err)
+		}
+		if id == "4" && (d.Get("volume_access_group_id").(int) != 2 || d.Get("virtual_network_ids.0").(int) != 7) {
+			t.Errorf("initiator 4 not read from the sweep: %v", d.State())
+		}
+	}
+	if calls := api.called(); len(calls) != 1 {
+		t.Errorf("expected one ListInitiators sweep for both reads, got %v", calls)
+	}
+}
+
+func TestInitiatorExistsTreatsUnknownAsGone(t *testing.T) {
+	api := newFakeAPI()
+	api.handle("ListInitiators", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		if p["initiators"].([]interface{})[0] == float64(4) {
+			return nil, &sdk.SdkError{Code: "xUnknown", Detail: "500:xUnknown"}
+		}
+		return nil, &sdk.SdkError{Code: "xPermissionDenied", Detail: "permission denied"}
+	})
+	client := newFakeAPIClient(t, api)
+	d := resourceElementSwInitiator().TestResourceData()
+
+	d.SetId("4")
+	if exists, err := resourceElementSwInitiatorExists(d, client); exists || err != nil {
+		t.Errorf("got %v, %v for 500:xUnknown; want false, nil", exists, err)
+	}
+	d.SetId("5")
+	if _, err := resourceElementSwInitiatorExists(d, client); err == nil {
+		t.Error("expected other errors to be returned")
+	}
+}
+
 func TestInitiator_basic(t *testing.T) {
 	var initiator initiator
 	resource.Test(t, resource.TestCase{
@@ -0,0 +1,95 @@
+package solidfire
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceElementSwKmipKeyProvider manages a KMIP key provider that groups key servers
+func resourceElementSwKmipKeyProvider() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElementSwKmipKeyProviderCreate,
+		Read:   resourceElementSwKmipKeyProviderRead,
+		Delete: resourceElementSwKmipKeyProviderDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true, // there is no ModifyKeyProviderKmip -> recreate to rename
+				Description: "Name of the KMIP key provider.",
+			},
+			"key_provider_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"is_active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the key provider is in use by encryption at rest.",
+			},
+			"key_server_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the key servers assigned to this provider.",
+			},
+			"kmip_capabilities": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceElementSwKmipKeyProviderCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	provider, err := client.CreateKeyProviderKmip(d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("CreateKeyProviderKmip failed: %w", err)
+	}
+	d.SetId(strconv.FormatInt(provider.KeyProviderID, 10))
+
+	return resourceElementSwKmipKeyProviderRead(d, meta)
+}
+
+func resourceElementSwKmipKeyProviderRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid key provider ID %q: %w", d.Id(), err)
+	}
+
+	provider, err := client.GetKeyProviderKmip(id)
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("key_provider_id", int(provider.KeyProviderID))
+	d.Set("name", provider.KeyProviderName)
+	d.Set("is_active", provider.KeyProviderIsActive)
+	d.Set("key_server_ids", provider.KeyServerIDs)
+	d.Set("kmip_capabilities", provider.KmipCapabilities)
+
+	return nil
+}
+
+func resourceElementSwKmipKeyProviderDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	if err := client.DeleteKeyProviderKmip(id); err != nil {
+		return fmt.Errorf("DeleteKeyProviderKmip failed: %w", err)
+	}
+	d.SetId("")
+	return nil
+}
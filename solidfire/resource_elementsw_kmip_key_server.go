@@ -0,0 +1,200 @@
+package solidfire
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceElementSwKmipKeyServer manages an external KMIP key server used for encryption at rest
+func resourceElementSwKmipKeyServer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElementSwKmipKeyServerCreate,
+		Read:   resourceElementSwKmipKeyServerRead,
+		Update: resourceElementSwKmipKeyServerUpdate,
+		Delete: resourceElementSwKmipKeyServerDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the KMIP key server.",
+			},
+			"hostnames": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Hostnames or IP addresses of the KMIP key server. The first entry is the primary, the rest are failover servers.",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     5696,
+				Description: "Port number of the KMIP key server.",
+			},
+			"ca_certificate": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "PEM-encoded public key certificate of the external key server's root CA.",
+			},
+			"client_certificate": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "PEM-encoded public key certificate used by the cluster as the KMIP client.",
+			},
+			"key_provider_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of the KMIP key provider this key server is assigned to.",
+			},
+			"test_connection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Run TestKeyServerKmip after the key server is created or changed and fail if the server is unreachable.",
+			},
+			"key_server_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"assigned_provider_is_active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the key provider this key server is assigned to is active.",
+			},
+		},
+	}
+}
+
+func resourceElementSwKmipKeyServerCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	params := map[string]interface{}{
+		"kmipKeyServerName":      d.Get("name").(string),
+		"kmipKeyServerHostnames": toStringSlice(d.Get("hostnames")),
+		"kmipKeyServerPort":      d.Get("port").(int),
+		"kmipCaCertificate":      d.Get("ca_certificate").(string),
+		"kmipClientCertificate":  d.Get("client_certificate").(string),
+	}
+
+	server, err := client.CreateKeyServerKmip(params)
+	if err != nil {
+		return fmt.Errorf("CreateKeyServerKmip failed: %w", err)
+	}
+	d.SetId(strconv.FormatInt(server.KeyServerID, 10))
+
+	if v, ok := d.GetOk("key_provider_id"); ok {
+		if err := client.AddKeyServerToProviderKmip(int64(v.(int)), server.KeyServerID); err != nil {
+			return fmt.Errorf("AddKeyServerToProviderKmip failed: %w", err)
+		}
+	}
+
+	if d.Get("test_connection").(bool) {
+		if err := client.TestKeyServerKmip(server.KeyServerID); err != nil {
+			return fmt.Errorf("TestKeyServerKmip failed for key server %d: %w", server.KeyServerID, err)
+		}
+	}
+
+	return resourceElementSwKmipKeyServerRead(d, meta)
+}
+
+func resourceElementSwKmipKeyServerRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid key server ID %q: %w", d.Id(), err)
+	}
+
+	server, err := client.GetKeyServerKmip(id)
+	if err != nil {
+		return err
+	}
+	if server == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("key_server_id", int(server.KeyServerID))
+	d.Set("name", server.KmipKeyServerName)
+	d.Set("hostnames", server.KmipKeyServerHostnames)
+	d.Set("port", int(server.KmipKeyServerPort))
+	d.Set("ca_certificate", server.KmipCaCertificate)
+	d.Set("client_certificate", server.KmipClientCertificate)
+	d.Set("key_provider_id", int(server.KeyProviderID))
+	d.Set("assigned_provider_is_active", server.KmipAssignedProviderIsActive)
+
+	return nil
+}
+
+func resourceElementSwKmipKeyServerUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	params := map[string]interface{}{
+		"keyServerID": id,
+	}
+	if d.HasChange("name") {
+		params["kmipKeyServerName"] = d.Get("name").(string)
+	}
+	if d.HasChange("hostnames") {
+		params["kmipKeyServerHostnames"] = toStringSlice(d.Get("hostnames"))
+	}
+	if d.HasChange("port") {
+		params["kmipKeyServerPort"] = d.Get("port").(int)
+	}
+	if d.HasChange("ca_certificate") {
+		params["kmipCaCertificate"] = d.Get("ca_certificate").(string)
+	}
+	if d.HasChange("client_certificate") {
+		params["kmipClientCertificate"] = d.Get("client_certificate").(string)
+	}
+	if len(params) > 1 {
+		if err := client.ModifyKeyServerKmip(params); err != nil {
+			return fmt.Errorf("ModifyKeyServerKmip failed: %w", err)
+		}
+	}
+
+	if d.HasChange("key_provider_id") {
+		oldRaw, newRaw := d.GetChange("key_provider_id")
+		if oldRaw.(int) != 0 {
+			if err := client.RemoveKeyServerFromProviderKmip(id); err != nil {
+				return fmt.Errorf("RemoveKeyServerFromProviderKmip failed: %w", err)
+			}
+		}
+		if newRaw.(int) != 0 {
+			if err := client.AddKeyServerToProviderKmip(int64(newRaw.(int)), id); err != nil {
+				return fmt.Errorf("AddKeyServerToProviderKmip failed: %w", err)
+			}
+		}
+	}
+
+	if d.Get("test_connection").(bool) {
+		if err := client.TestKeyServerKmip(id); err != nil {
+			return fmt.Errorf("TestKeyServerKmip failed for key server %d: %w", id, err)
+		}
+	}
+
+	return resourceElementSwKmipKeyServerRead(d, meta)
+}
+
+func resourceElementSwKmipKeyServerDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	// A key server must be unassigned before it can be deleted
+	if v, ok := d.GetOk("key_provider_id"); ok && v.(int) != 0 {
+		if err := client.RemoveKeyServerFromProviderKmip(id); err != nil {
+			return fmt.Errorf("RemoveKeyServerFromProviderKmip failed: %w", err)
+		}
+	}
+
+	if err := client.DeleteKeyServerKmip(id); err != nil {
+		return fmt.Errorf("DeleteKeyServerKmip failed: %w", err)
+	}
+	d.SetId("")
+	return nil
+}
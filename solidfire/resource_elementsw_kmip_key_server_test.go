@@ -0,0 +1,86 @@
+package solidfire
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func TestKmipReadsForgetDeletedObjects(t *testing.T) {
+	api := newFakeAPI()
+	api.handle("GetKeyServerKmip", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return nil, &sdk.SdkError{Code: "xKeyServerDoesNotExist", Detail: "Key server 3 does not exist."}
+	})
+	api.handle("GetKeyProviderKmip", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{}, nil
+	})
+	client := newFakeAPIClient(t, api)
+
+	for name, r := range map[string]*schema.Resource{
+		"key server":   resourceElementSwKmipKeyServer(),
+		"key provider": resourceElementSwKmipKeyProvider(),
+	} {
+		d := r.TestResourceData()
+		d.SetId("3")
+		if err := r.Read(d, client); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if d.Id() != "" {
+			t.Errorf("%s deleted outside Terraform was kept in state", name)
+		}
+	}
+}
+
+func TestAccElementswKmipKeyServer_basic(t *testing.T) {
+	kmipServer := os.Getenv("SOLIDFIRE_KMIP_SERVER")
+	caCert := os.Getenv("SOLIDFIRE_KMIP_CA_CERT")
+	clientCert := os.Getenv("SOLIDFIRE_KMIP_CLIENT_CERT")
+	if kmipServer == "" || caCert == "" || clientCert == "" {
+		t.Skip("SOLIDFIRE_KMIP_SERVER, SOLIDFIRE_KMIP_CA_CERT and SOLIDFIRE_KMIP_CLIENT_CERT must be set for KMIP tests")
+	}
+
+	resourceName := "solidfire_kmip_key_server.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKmipKeyServerConfig(kmipServer, caCert, clientCert),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "key_server_id"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-kmip-server"),
+					resource.TestCheckResourceAttr(resourceName, "hostnames.0", kmipServer),
+					resource.TestCheckResourceAttrPair(resourceName, "key_provider_id", "solidfire_kmip_key_provider.test", "key_provider_id"),
+					resource.TestCheckResourceAttr("solidfire_kmip_key_provider.test", "name", "tf-acc-kmip-provider"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"test_connection"},
+			},
+		},
+	})
+}
+
+func testAccKmipKeyServerConfig(server, caCert, clientCert string) string {
+	return fmt.Sprintf(`
+resource "solidfire_kmip_key_provider" "test" {
+  name = "tf-acc-kmip-provider"
+}
+
+resource "solidfire_kmip_key_server" "test" {
+  name               = "tf-acc-kmip-server"
+  hostnames          = ["%s"]
+  ca_certificate     = file("%s")
+  client_certificate = file("%s")
+  key_provider_id    = solidfire_kmip_key_provider.test.key_provider_id
+  test_connection    = true
+}
+`, server, caCert, clientCert)
+}
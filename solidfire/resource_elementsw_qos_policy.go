@@ -1,6 +1,7 @@
 package solidfire
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -17,6 +18,13 @@ func resourceElementswQoSPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: func(_ context.Context, _ *schema.ResourceDiff, meta interface{}) error {
+			client, ok := meta.(*Client)
+			if !ok {
+				return nil
+			}
+			return client.requireAPIFeature(featureQoSPolicies, "solidfire_qos_policy")
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
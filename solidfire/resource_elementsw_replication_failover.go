@@ -0,0 +1,235 @@
+package solidfire
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceElementSwReplicationFailover manages which site of a group of paired volumes accepts
+// writes. The provider's cluster is the primary site, the target cluster the secondary site.
+func resourceElementSwReplicationFailover() *schema.Resource {
+	r := &schema.Resource{
+		Create: resourceElementSwReplicationFailoverCreate,
+		Read:   resourceElementSwReplicationFailoverRead,
+		Update: resourceElementSwReplicationFailoverUpdate,
+		Delete: resourceElementSwReplicationFailoverDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"volume_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The paired volumes on the primary (provider) cluster that fail over together, e.g. from solidfire_volume_pairing.",
+			},
+			"target_cluster":         clusterConnectionSchema("Secondary cluster holding the paired volumes (API endpoint, username, password)"),
+			"target_cluster_profile": clusterProfileNameSchema("Name of the provider's cluster profile to use as the secondary cluster, instead of target_cluster."),
+			"active_site": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{sitePrimary, siteSecondary}, false),
+				Description:  "The site whose volumes accept writes: `primary` or `secondary`. Changing it fails over (or back).",
+			},
+			"failover_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      failoverPlanned,
+				ValidateFunc: validation.StringInSlice([]string{failoverPlanned, failoverUnplanned}, false),
+				Description:  "`planned` stops writes, waits until the pairs are in sync and swaps roles. `unplanned` only promotes the new active site, for when the other site is unavailable.",
+			},
+			"volumes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The volumes of the group and their access on each site.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"primary_volume_id":   {Type: schema.TypeInt, Computed: true},
+						"secondary_volume_id": {Type: schema.TypeInt, Computed: true},
+						"primary_access":      {Type: schema.TypeString, Computed: true},
+						"secondary_access":    {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+	clusterAlternatives(r.Schema, "target_cluster", "target_cluster_profile", true)
+	return r
+}
+
+// failoverClients returns the clients of the primary and secondary sites
+func failoverClients(d *schema.ResourceData, meta interface{}) (map[string]*Client, error) {
+	secondary, err := remoteClusterClient(d, meta, "target_cluster", "target_cluster_profile")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create target cluster client: %w", err)
+	}
+	if secondary == nil {
+		return nil, fmt.Errorf("invalid target_cluster connection info")
+	}
+	return map[string]*Client{sitePrimary: meta.(*Client), siteSecondary: secondary}, nil
+}
+
+// flattenedFailoverVolumes returns the group recorded in state
+func flattenedFailoverVolumes(d *schema.ResourceData) []failoverVolume {
+	var vols []failoverVolume
+	for _, raw := range d.Get("volumes").([]interface{}) {
+		m := raw.(map[string]interface{})
+		vols = append(vols, failoverVolume{
+			PrimaryID:       int64(m["primary_volume_id"].(int)),
+			SecondaryID:     int64(m["secondary_volume_id"].(int)),
+			PrimaryAccess:   m["primary_access"].(string),
+			SecondaryAccess: m["secondary_access"].(string),
+		})
+	}
+	return vols
+}
+
+func setFailoverVolumes(d *schema.ResourceData, vols []failoverVolume) {
+	list := make([]map[string]interface{}, 0, len(vols))
+	for _, v := range vols {
+		list = append(list, map[string]interface{}{
+			"primary_volume_id":   int(v.PrimaryID),
+			"secondary_volume_id": int(v.SecondaryID),
+			"primary_access":      v.PrimaryAccess,
+			"secondary_access":    v.SecondaryAccess,
+		})
+	}
+	d.Set("volumes", list)
+}
+
+// refreshFailoverAccess updates the access of each volume on the sites that can be reached.
+// A site that cannot be reached keeps its last known access, so that an unplanned failover
+// can still be planned and applied while the primary site is down.
+func refreshFailoverAccess(vols []failoverVolume, clients map[string]*Client) []error {
+	var errs []error
+	for _, site := range []string{sitePrimary, siteSecondary} {
+		ids := make([]int64, 0, len(vols))
+		for _, v := range vols {
+			ids = append(ids, v.id(site))
+		}
+		found, err := clients[site].ListVolumes(ids)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s site: %w", site, err))
+			continue
+		}
+		access := make(map[int64]string, len(found))
+		for _, vol := range found {
+			access[vol.VolumeID] = vol.Access
+		}
+		for i := range vols {
+			a, ok := access[vols[i].id(site)]
+			if !ok {
+				errs = append(errs, fmt.Errorf("volume %d not found on the %s site", vols[i].id(site), site))
+				continue
+			}
+			if site == sitePrimary {
+				vols[i].PrimaryAccess = a
+			} else {
+				vols[i].SecondaryAccess = a
+			}
+		}
+	}
+	return errs
+}
+
+func resourceElementSwReplicationFailoverCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	clients, err := failoverClients(d, meta)
+	if err != nil {
+		return err
+	}
+
+	// Map each primary volume to its secondary volume through its pair
+	var vols []failoverVolume
+	var ids []string
+	for _, id := range toInt64Slice(d.Get("volume_ids")) {
+		paired, err := client.getPairedVolume(id)
+		if err != nil {
+			return fmt.Errorf("failed to read volume pair of %d: %w", id, err)
+		}
+		if paired == nil {
+			return fmt.Errorf("volume %d is not paired", id)
+		}
+		if len(paired.VolumePairs) > 1 {
+			return fmt.Errorf("volume %d has %d pairs, failover needs exactly one", id, len(paired.VolumePairs))
+		}
+		vols = append(vols, failoverVolume{PrimaryID: id, SecondaryID: paired.VolumePairs[0].RemoteVolumeID})
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	if errs := refreshFailoverAccess(vols, clients); len(errs) > 0 {
+		return errs[0]
+	}
+
+	d.SetId("failover-" + strings.Join(ids, "-"))
+	setFailoverVolumes(d, vols)
+	// The configured site is taken to hold the current data
+	return applyFailover(d, vols, d.Get("active_site").(string), clients, d.Timeout(schema.TimeoutCreate))
+}
+
+func resourceElementSwReplicationFailoverRead(d *schema.ResourceData, meta interface{}) error {
+	clients, err := failoverClients(d, meta)
+	if err != nil {
+		return err
+	}
+	vols := flattenedFailoverVolumes(d)
+	for _, err := range refreshFailoverAccess(vols, clients) {
+		tflog.SubsystemWarn(clients[sitePrimary].logContext(), logReplication, "Could not read volume access", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	setFailoverVolumes(d, vols)
+	// Mixed or split-brain groups keep the configured site, so the next apply completes the move
+	if site := activeSite(vols); site != "" {
+		d.Set("active_site", site)
+	}
+	return nil
+}
+
+func resourceElementSwReplicationFailoverUpdate(d *schema.ResourceData, meta interface{}) error {
+	clients, err := failoverClients(d, meta)
+	if err != nil {
+		return err
+	}
+	vols := flattenedFailoverVolumes(d)
+	errs := refreshFailoverAccess(vols, clients)
+	if len(errs) > 0 && d.Get("failover_mode").(string) == failoverPlanned {
+		return fmt.Errorf("a planned failover needs both sites, use failover_mode = \"unplanned\" if one is down: %w", errs[0])
+	}
+	previous, _ := d.GetChange("active_site")
+	return applyFailover(d, vols, previous.(string), clients, d.Timeout(schema.TimeoutUpdate))
+}
+
+// applyFailover moves the group to the configured active site and records the resulting roles
+func applyFailover(d *schema.ResourceData, vols []failoverVolume, previous string, clients map[string]*Client, timeout time.Duration) error {
+	site := d.Get("active_site").(string)
+	mode := d.Get("failover_mode").(string)
+	steps := planFailover(vols, site, previous, mode)
+	if len(steps) > 0 {
+		tflog.SubsystemInfo(clients[sitePrimary].logContext(), logReplication, "Failing over volume group", map[string]interface{}{
+			"active_site": site,
+			"mode":        mode,
+			"steps":       len(steps),
+		})
+	}
+	err := runFailoverSteps(steps, clients, timeout)
+
+	// Record the roles as they are now, also after a partial failover
+	refreshFailoverAccess(vols, clients)
+	setFailoverVolumes(d, vols)
+	return err
+}
+
+// resourceElementSwReplicationFailoverDelete only forgets the group; volume roles stay as they are
+func resourceElementSwReplicationFailoverDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -1,11 +1,22 @@
 package solidfire
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/scaleoutsean/solidfire-go/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Schedule frequencies. Element's scheduleType is always "Snapshot"; the frequency is kept in
+// attributes.frequency and decides what hours, minutes, weekdays and monthdays mean.
+const (
+	scheduleTimeInterval = "Time Interval"
+	scheduleDaysOfWeek   = "Days Of Week"
+	scheduleDaysOfMonth  = "Days Of Month"
+
+	scheduleAPIType = "Snapshot"
 )
 
 func resourceElementswSchedule() *schema.Resource {
@@ -17,108 +28,288 @@ func resourceElementswSchedule() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceElementswScheduleCustomizeDiff,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceElementswScheduleV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceElementswScheduleStateUpgradeV0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"schedule_name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the schedule.",
 			},
 			"schedule_type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{scheduleTimeInterval, scheduleDaysOfWeek, scheduleDaysOfMonth}, false),
+				Description:  "How often the schedule runs: `Time Interval` (every `hours` and `minutes`), `Days Of Week` (on `weekdays` at `hours`:`minutes`) or `Days Of Month` (on `monthdays` at `hours`:`minutes`).",
 			},
 			"attributes": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Attributes of the schedule. The frequency is set with `schedule_type`, not with a `frequency` attribute.",
 			},
 			"minutes": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  0,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 59),
+				Description:  "Minutes of the interval, or minute of the hour the schedule runs at.",
 			},
 			"hours": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  0,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Hours of the interval, or hour of the day (0-23) the schedule runs at.",
+			},
+			"weekdays": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Days a `Days Of Week` schedule runs on.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"day": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 6),
+							Description:  "Day of the week, 0 (Sunday) to 6 (Saturday).",
+						},
+						"offset": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Week offset of the day. Defaults to 1, every week.",
+						},
+					},
+				},
 			},
 			"schedule_info": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The snapshot the schedule takes. With several volumes it takes a group snapshot.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"volume_ids": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "Volumes to snapshot. More than one volume makes a crash-consistent group snapshot.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the snapshots. Defaults to their creation time.",
+						},
+						"retention": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringMatch(retentionRegexp, "must be HH:mm:ss"),
+							Description:  "How long to keep each snapshot, as HH:mm:ss. Snapshots are kept until deleted when not set.",
+						},
+						"snapmirror_label": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Label SnapMirror policies use to select the snapshots for replication to ONTAP.",
+						},
+						"enable_remote_replication": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Replicate the snapshots to the paired volumes.",
+						},
+						"ensure_serial_creation": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Skip a run while the previous snapshot is still being replicated.",
+						},
+					},
+				},
 			},
 			"paused": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Do not run the schedule.",
 			},
 			"recurring": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Run the schedule repeatedly instead of once.",
 			},
 			"run_next_interval": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Run the schedule the next time it is checked, regardless of its frequency.",
 			},
 			"starting_date": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "When the schedule starts, as an RFC 3339 time. Defaults to its creation.",
 			},
 			"monthdays": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt, ValidateFunc: validation.IntBetween(1, 31)},
+				Description: "Days of the month, 1 to 31, a `Days Of Month` schedule runs on.",
+			},
+			"last_run_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Result of the last run, e.g. `Success` or `Failed`.",
+			},
+			"last_run_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the last run started.",
 			},
 		},
 	}
 }
 
-func resourceElementswScheduleCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
-	req := sdk.CreateScheduleRequest{
-		ScheduleName:    d.Get("schedule_name").(string),
-		ScheduleType:    d.Get("schedule_type").(string),
-		Hours:           int64(d.Get("hours").(int)),
-		Minutes:         int64(d.Get("minutes").(int)),
-		Paused:          d.Get("paused").(bool),
-		Recurring:       d.Get("recurring").(bool),
-		RunNextInterval: d.Get("run_next_interval").(bool),
+// expandScheduleInfo returns the scheduleInfo parameter of a schedule_info block
+func expandScheduleInfo(v interface{}) map[string]interface{} {
+	list := v.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
 	}
+	m := list[0].(map[string]interface{})
 
-	if v, ok := d.GetOk("starting_date"); ok {
-		req.StartingDate = v.(string)
+	var label interface{}
+	if l := m["snapmirror_label"].(string); l != "" {
+		label = l
+	}
+	info := buildScheduleInfo(toInt64Slice(m["volume_ids"].(*schema.Set).List()), m["retention"].(string), label)
+	if name := m["name"].(string); name != "" {
+		info["name"] = name
+	}
+	if m["enable_remote_replication"].(bool) {
+		info["enableRemoteReplication"] = true
+	}
+	if m["ensure_serial_creation"].(bool) {
+		info["ensureSerialCreation"] = true
 	}
+	return info
+}
 
-	if v, ok := d.GetOk("attributes"); ok {
-		req.Attributes = v
+func flattenScheduleInfo(info scheduleInfo) []interface{} {
+	volumeIDs := []interface{}{}
+	for _, id := range info.volumeIDs() {
+		volumeIDs = append(volumeIDs, int(id))
 	}
+	return []interface{}{map[string]interface{}{
+		"volume_ids":                volumeIDs,
+		"name":                      info.Name,
+		"retention":                 info.Retention,
+		"snapmirror_label":          info.SnapMirrorLabel,
+		"enable_remote_replication": info.EnableRemoteReplication,
+		"ensure_serial_creation":    info.EnsureSerialCreation,
+	}}
+}
 
-	if v, ok := d.GetOk("schedule_info"); ok {
-		m := v.(map[string]interface{})
-		info := sdk.ScheduleInfo{}
-		if val, ok := m["volumeID"]; ok {
-			id, _ := strconv.ParseInt(val.(string), 10, 64)
-			info.VolumeID = id
-		}
-		if val, ok := m["retention"]; ok {
-			info.Retention = val.(string)
+// resourceElementswScheduleCustomizeDiff rejects frequency settings Element would refuse
+func resourceElementswScheduleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateScheduleFrequency(d); err != nil {
+		return err
+	}
+	return requireAPIFeatures(map[string]apiFeature{
+		"schedule_info.0.snapmirror_label": featureSnapMirror,
+	})(ctx, d, meta)
+}
+
+func validateScheduleFrequency(d *schema.ResourceDiff) error {
+	for _, k := range []string{"schedule_type", "attributes", "hours", "minutes", "weekdays", "monthdays"} {
+		if !d.NewValueKnown(k) {
+			return nil
 		}
-		req.ScheduleInfo = info
+	}
+	if _, ok := d.Get("attributes").(map[string]interface{})["frequency"]; ok {
+		return fmt.Errorf("set the frequency with schedule_type instead of attributes.frequency")
 	}
 
-	if v, ok := d.GetOk("monthdays"); ok {
-		days := v.([]interface{})
-		intDays := make([]int64, len(days))
-		for i, day := range days {
-			intDays[i] = int64(day.(int))
+	frequency := d.Get("schedule_type").(string)
+	hours, minutes := d.Get("hours").(int), d.Get("minutes").(int)
+	weekdays := d.Get("weekdays").(*schema.Set).Len()
+	monthdays := len(d.Get("monthdays").([]interface{}))
+	switch frequency {
+	case scheduleTimeInterval:
+		if weekdays > 0 || monthdays > 0 {
+			return fmt.Errorf("a %q schedule runs every hours and minutes and takes no weekdays or monthdays", frequency)
+		}
+		if hours == 0 && minutes == 0 {
+			return fmt.Errorf("a %q schedule needs an interval: set hours or minutes", frequency)
 		}
-		req.Monthdays = intDays
+	case scheduleDaysOfWeek:
+		if weekdays == 0 || monthdays > 0 {
+			return fmt.Errorf("a %q schedule needs weekdays and takes no monthdays", frequency)
+		}
+	case scheduleDaysOfMonth:
+		if monthdays == 0 || weekdays > 0 {
+			return fmt.Errorf("a %q schedule needs monthdays and takes no weekdays", frequency)
+		}
+	}
+	if frequency != scheduleTimeInterval && hours > 23 {
+		return fmt.Errorf("a %q schedule runs at hours:minutes, so hours must be 0 to 23, got %d", frequency, hours)
+	}
+	return nil
+}
+
+// scheduleFrequencyParams returns the parameters that make up the frequency of a schedule. They
+// are always sent together, as hours and minutes mean something else for each frequency.
+func scheduleFrequencyParams(d *schema.ResourceData) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	for k, v := range d.Get("attributes").(map[string]interface{}) {
+		attributes[k] = v
+	}
+	attributes["frequency"] = d.Get("schedule_type").(string)
+
+	weekdays := []interface{}{}
+	for _, v := range d.Get("weekdays").(*schema.Set).List() {
+		w := v.(map[string]interface{})
+		weekdays = append(weekdays, map[string]interface{}{
+			"day":    w["day"].(int),
+			"offset": w["offset"].(int),
+		})
+	}
+
+	return map[string]interface{}{
+		"scheduleType": scheduleAPIType,
+		"attributes":   attributes,
+		"hours":        d.Get("hours").(int),
+		"minutes":      d.Get("minutes").(int),
+		"weekdays":     weekdays,
+		"monthdays":    toInt64Slice(d.Get("monthdays")),
+	}
+}
+
+func resourceElementswScheduleCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+	params := scheduleFrequencyParams(d)
+	params["scheduleName"] = d.Get("schedule_name").(string)
+	params["paused"] = d.Get("paused").(bool)
+	params["recurring"] = d.Get("recurring").(bool)
+	params["runNextInterval"] = d.Get("run_next_interval").(bool)
+	params["scheduleInfo"] = expandScheduleInfo(d.Get("schedule_info"))
+
+	if v, ok := d.GetOk("starting_date"); ok {
+		params["startingDate"] = v.(string)
 	}
 
-	id, err := client.CreateSchedule(&req)
+	id, err := client.CreateSchedule(params)
 	if err != nil {
-		return err
+		return fmt.Errorf("CreateSchedule failed: %w", err)
 	}
 	d.SetId(fmt.Sprintf("%d", id))
 	return resourceElementswScheduleRead(d, m)
@@ -136,8 +327,19 @@ func resourceElementswScheduleRead(d *schema.ResourceData, m interface{}) error
 		return nil
 	}
 
+	attributes := flattenAttributes(s.Attributes)
+	frequency, _ := attributes["frequency"].(string)
+	delete(attributes, "frequency")
+	weekdays := make([]interface{}, 0, len(s.Weekdays))
+	for _, w := range s.Weekdays {
+		weekdays = append(weekdays, map[string]interface{}{
+			"day":    int(w.Day),
+			"offset": int(w.Offset),
+		})
+	}
+
 	d.Set("schedule_name", s.ScheduleName)
-	d.Set("schedule_type", s.ScheduleType)
+	d.Set("schedule_type", frequency)
 	d.Set("hours", int(s.Hours))
 	d.Set("minutes", int(s.Minutes))
 	d.Set("paused", s.Paused)
@@ -145,26 +347,47 @@ func resourceElementswScheduleRead(d *schema.ResourceData, m interface{}) error
 	d.Set("run_next_interval", s.RunNextInterval)
 	d.Set("starting_date", s.StartingDate)
 	d.Set("monthdays", s.Monthdays)
-	return nil
+	d.Set("last_run_status", s.LastRunStatus)
+	d.Set("last_run_time", s.LastRunTimeStarted)
+	if err := d.Set("attributes", attributes); err != nil {
+		return err
+	}
+	if err := d.Set("weekdays", weekdays); err != nil {
+		return err
+	}
+	return d.Set("schedule_info", flattenScheduleInfo(s.ScheduleInfo))
 }
 
 func resourceElementswScheduleUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	req := sdk.ModifyScheduleRequest{
-		ScheduleID: id,
-	}
+	params := map[string]interface{}{}
 
+	if d.HasChanges("schedule_type", "attributes", "hours", "minutes", "weekdays", "monthdays") {
+		params = scheduleFrequencyParams(d)
+	}
+	params["scheduleID"] = id
 	if d.HasChange("schedule_name") {
-		req.ScheduleName = d.Get("schedule_name").(string)
+		params["scheduleName"] = d.Get("schedule_name").(string)
 	}
 	if d.HasChange("paused") {
-		req.Paused = d.Get("paused").(bool)
+		params["paused"] = d.Get("paused").(bool)
+	}
+	if d.HasChange("recurring") {
+		params["recurring"] = d.Get("recurring").(bool)
+	}
+	if d.HasChange("run_next_interval") {
+		params["runNextInterval"] = d.Get("run_next_interval").(bool)
+	}
+	if v := d.Get("starting_date").(string); d.HasChange("starting_date") && v != "" {
+		params["startingDate"] = v
+	}
+	if d.HasChange("schedule_info") {
+		params["scheduleInfo"] = expandScheduleInfo(d.Get("schedule_info"))
 	}
 
-	err := client.ModifySchedule(&req)
-	if err != nil {
-		return err
+	if err := client.ModifySchedule(params); err != nil {
+		return fmt.Errorf("ModifySchedule failed: %w", err)
 	}
 	return resourceElementswScheduleRead(d, m)
 }
@@ -172,9 +395,56 @@ func resourceElementswScheduleUpdate(d *schema.ResourceData, m interface{}) erro
 func resourceElementswScheduleDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	req := sdk.ModifyScheduleRequest{
-		ScheduleID:  id,
-		ToBeDeleted: true,
+	if err := client.ModifySchedule(map[string]interface{}{
+		"scheduleID":  id,
+		"toBeDeleted": true,
+	}); err != nil {
+		return fmt.Errorf("ModifySchedule failed: %w", err)
+	}
+	return nil
+}
+
+// resourceElementswScheduleV0 is the schema before schedule_info became a block, when it was a
+// map of scheduleInfo parameters such as volumeID and retention
+func resourceElementswScheduleV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"schedule_name":     {Type: schema.TypeString, Required: true},
+			"schedule_type":     {Type: schema.TypeString, Required: true},
+			"attributes":        {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"minutes":           {Type: schema.TypeInt, Optional: true},
+			"hours":             {Type: schema.TypeInt, Optional: true},
+			"schedule_info":     {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"paused":            {Type: schema.TypeBool, Optional: true},
+			"recurring":         {Type: schema.TypeBool, Optional: true},
+			"run_next_interval": {Type: schema.TypeBool, Optional: true},
+			"starting_date":     {Type: schema.TypeString, Optional: true},
+			"monthdays":         {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeInt}},
+		},
+	}
+}
+
+// resourceElementswScheduleStateUpgradeV0 turns the schedule_info map into a block
+func resourceElementswScheduleStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	old, _ := rawState["schedule_info"].(map[string]interface{})
+	if len(old) == 0 {
+		rawState["schedule_info"] = []interface{}{}
+		return rawState, nil
+	}
+
+	info := map[string]interface{}{
+		"volume_ids": []interface{}{},
+	}
+	if v, ok := old["volumeID"].(string); ok {
+		if id, err := strconv.Atoi(v); err == nil {
+			info["volume_ids"] = []interface{}{id}
+		}
+	}
+	for oldKey, key := range map[string]string{"retention": "retention", "name": "name", "snapMirrorLabel": "snapmirror_label"} {
+		if v, ok := old[oldKey].(string); ok {
+			info[key] = v
+		}
 	}
-	return client.ModifySchedule(&req)
+	rawState["schedule_info"] = []interface{}{info}
+	return rawState, nil
 }
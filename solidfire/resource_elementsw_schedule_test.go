@@ -1,10 +1,15 @@
 package solidfire
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
 func TestAccElementswSchedule_basic(t *testing.T) {
@@ -19,14 +24,14 @@ func TestAccElementswSchedule_basic(t *testing.T) {
 				Config: testAccScheduleConfig(scheduleName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "schedule_name", scheduleName),
-					resource.TestCheckResourceAttr(resourceName, "schedule_type", "Snapshot"),
+					resource.TestCheckResourceAttr(resourceName, "schedule_type", "Time Interval"),
+					resource.TestCheckResourceAttrSet(resourceName, "starting_date"),
 				),
 			},
 			{
-				ResourceName:            resourceName,
-				ImportState:             true,
-				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"attributes", "schedule_info"},
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
 			},
 		},
 	})
@@ -47,14 +52,11 @@ resource "solidfire_volume" "test" {
 
 resource "solidfire_schedule" "test" {
   schedule_name = "%s"
-  schedule_type = "Snapshot"
-  attributes = {
-    frequency = "Time Interval"
-  }
+  schedule_type = "Time Interval"
   minutes = 10
-  schedule_info = {
-    retention = "0:10:00"
-    volumeID = "${solidfire_volume.test.id}"
+  schedule_info {
+    volume_ids = [solidfire_volume.test.id]
+    retention  = "0:10:00"
   }
   paused = false
   recurring = true
@@ -62,4 +64,279 @@ resource "solidfire_schedule" "test" {
 `, name)
 }
 
-// Additional tests for List, Get, Modify can be added here as needed.
+// fakeSchedules answers the schedule methods from the map it returns, keyed by schedule ID
+func fakeSchedules(api *fakeAPI) map[int64]map[string]interface{} {
+	schedules := map[int64]map[string]interface{}{}
+	nextID := int64(1)
+	api.handle("CreateSchedule", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		s := map[string]interface{}{"scheduleID": nextID}
+		for k, v := range p {
+			s[k] = v
+		}
+		schedules[nextID] = s
+		nextID++
+		return map[string]interface{}{"scheduleID": s["scheduleID"]}, nil
+	})
+	api.handle("ListSchedules", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		list := []interface{}{}
+		for _, s := range schedules {
+			list = append(list, s)
+		}
+		return map[string]interface{}{"schedules": list}, nil
+	})
+	api.handle("ModifySchedule", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		s, ok := schedules[int64(p["scheduleID"].(float64))]
+		if !ok {
+			return nil, &sdk.SdkError{Code: "xScheduleDoesNotExist"}
+		}
+		for k, v := range p {
+			s[k] = v
+		}
+		return map[string]interface{}{"schedule": s}, nil
+	})
+	return schedules
+}
+
+func TestScheduleGroupSnapshotInfo(t *testing.T) {
+	api := newFakeAPI()
+	schedules := fakeSchedules(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementswSchedule()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"schedule_name": "hourly",
+		"schedule_type": "Time Interval",
+		"minutes":       30,
+		"recurring":     true,
+		"schedule_info": []interface{}{map[string]interface{}{
+			"volume_ids":                []interface{}{5, 6},
+			"name":                      "hourly-db",
+			"retention":                 "24:00:00",
+			"snapmirror_label":          "hourly",
+			"enable_remote_replication": true,
+			"ensure_serial_creation":    true,
+		}},
+	})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	info := api.lastParams("CreateSchedule")["scheduleInfo"].(map[string]interface{})
+	if _, single := info["volumeID"]; single || len(info["volumes"].([]interface{})) != 2 {
+		t.Errorf("a schedule of two volumes should send volumes: %v", info)
+	}
+	for k, want := range map[string]interface{}{
+		"name": "hourly-db", "retention": "24:00:00", "snapMirrorLabel": "hourly",
+		"enableRemoteReplication": true, "ensureSerialCreation": true,
+	} {
+		if info[k] != want {
+			t.Errorf("scheduleInfo %s = %v, want %v", k, info[k], want)
+		}
+	}
+
+	// Element releases report volume IDs as strings too
+	schedules[1]["scheduleInfo"] = map[string]interface{}{
+		"volumes": []interface{}{"5", "7"}, "name": "hourly-db", "retention": "48:00:00", "snapMirrorLabel": "hourly",
+	}
+	if err := r.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	ids := d.Get("schedule_info.0.volume_ids").(*schema.Set)
+	if ids.Len() != 2 || !ids.Contains(7) || d.Get("schedule_info.0.retention") != "48:00:00" || d.Get("schedule_info.0.enable_remote_replication").(bool) {
+		t.Errorf("schedule_info not read back: %v", d.Get("schedule_info"))
+	}
+}
+
+func TestScheduleSingleVolumeInfo(t *testing.T) {
+	api := newFakeAPI()
+	fakeSchedules(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementswSchedule()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"schedule_name": "nightly",
+		"schedule_type": "Days Of Week",
+		"hours":         2,
+		"weekdays":      []interface{}{map[string]interface{}{"day": 1, "offset": 1}},
+		"schedule_info": []interface{}{map[string]interface{}{
+			"volume_ids": []interface{}{5},
+		}},
+	})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"volumeID": float64(5)}
+	if got := api.lastParams("CreateSchedule")["scheduleInfo"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("scheduleInfo = %v, want %v", got, want)
+	}
+	if d.Get("schedule_info.0.volume_ids").(*schema.Set).Len() != 1 {
+		t.Errorf("schedule_info not read back: %v", d.Get("schedule_info"))
+	}
+}
+
+func TestScheduleStateUpgradeV0(t *testing.T) {
+	state, err := resourceElementswScheduleStateUpgradeV0(context.Background(), map[string]interface{}{
+		"schedule_name": "nightly",
+		"schedule_info": map[string]interface{}{"volumeID": "5", "retention": "0:10:00"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{map[string]interface{}{
+		"volume_ids": []interface{}{5},
+		"retention":  "0:10:00",
+	}}
+	if !reflect.DeepEqual(state["schedule_info"], want) {
+		t.Errorf("schedule_info = %v, want %v", state["schedule_info"], want)
+	}
+}
+
+func TestScheduleFrequencyParams(t *testing.T) {
+	api := newFakeAPI()
+	schedules := fakeSchedules(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementswSchedule()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"schedule_name": "weekdays",
+		"schedule_type": "Days Of Week",
+		"attributes":    map[string]interface{}{"owner": "db"},
+		"hours":         22,
+		"minutes":       30,
+		"weekdays": []interface{}{
+			map[string]interface{}{"day": 1, "offset": 1},
+			map[string]interface{}{"day": 5, "offset": 1},
+		},
+		"schedule_info": []interface{}{map[string]interface{}{"volume_ids": []interface{}{5}}},
+	})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	p := api.lastParams("CreateSchedule")
+	if p["scheduleType"] != "Snapshot" || !reflect.DeepEqual(p["attributes"], map[string]interface{}{"owner": "db", "frequency": "Days Of Week"}) {
+		t.Errorf("the frequency should be sent as attributes.frequency of a Snapshot schedule: %v", p)
+	}
+	if len(p["weekdays"].([]interface{})) != 2 || p["hours"] != float64(22) {
+		t.Errorf("unexpected CreateSchedule params: %v", p)
+	}
+
+	schedules[1]["lastRunStatus"] = "Success"
+	schedules[1]["lastRunTimeStarted"] = "2026-10-19T22:30:00Z"
+	if err := r.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.Get("attributes").(map[string]interface{})["frequency"]; ok || d.Get("schedule_type") != "Days Of Week" || d.Get("attributes.owner") != "db" {
+		t.Errorf("schedule_type and attributes not read back: %v", d.State().Attributes)
+	}
+	if d.Get("weekdays").(*schema.Set).Len() != 2 || d.Get("last_run_status") != "Success" || d.Get("last_run_time") != "2026-10-19T22:30:00Z" {
+		t.Errorf("unexpected state after read: %v", d.State().Attributes)
+	}
+}
+
+func TestScheduleUpdateInPlace(t *testing.T) {
+	api := newFakeAPI()
+	schedules := fakeSchedules(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementswSchedule()
+
+	schedules[1] = map[string]interface{}{
+		"scheduleID": 1, "scheduleName": "nightly", "scheduleType": "Snapshot",
+		"attributes": map[string]interface{}{"frequency": "Time Interval"}, "hours": 24, "paused": true, "recurring": true,
+		"scheduleInfo": map[string]interface{}{"volumeID": 5},
+	}
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id": "1", "schedule_name": "nightly", "schedule_type": "Time Interval", "hours": "24", "minutes": "0",
+			"paused": "true", "recurring": "true", "run_next_interval": "false", "starting_date": "2026-10-01T00:00:00Z",
+			"schedule_info.#": "1", "schedule_info.0.volume_ids.#": "1", "schedule_info.0.volume_ids.5": "5",
+		},
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"schedule_name": "nightly",
+		"schedule_type": "Days Of Month",
+		"hours":         1,
+		"monthdays":     []interface{}{1, 15},
+		"recurring":     true,
+		"schedule_info": []interface{}{map[string]interface{}{"volume_ids": []interface{}{5, 6}}},
+	}), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.RequiresNew() {
+		t.Fatal("schedule changes should be made in place")
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Update(d, client); err != nil {
+		t.Fatal(err)
+	}
+	p := api.lastParams("ModifySchedule")
+	want := map[string]interface{}{
+		"scheduleID":   float64(1),
+		"scheduleType": "Snapshot",
+		"attributes":   map[string]interface{}{"frequency": "Days Of Month"},
+		"hours":        float64(1),
+		"minutes":      float64(0),
+		"weekdays":     []interface{}{},
+		"monthdays":    []interface{}{float64(1), float64(15)},
+		"paused":       false,
+		"scheduleInfo": map[string]interface{}{"volumes": p["scheduleInfo"].(map[string]interface{})["volumes"]},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("ModifySchedule params = %v, want %v", p, want)
+	}
+	if d.Get("schedule_type") != "Days Of Month" || d.Get("monthdays.#") != 2 || d.Get("schedule_info.0.volume_ids").(*schema.Set).Len() != 2 {
+		t.Errorf("unexpected state after update: %v", d.State().Attributes)
+	}
+}
+
+func TestScheduleFrequencyValidation(t *testing.T) {
+	base := func(extra map[string]interface{}) map[string]interface{} {
+		cfg := map[string]interface{}{
+			"schedule_name": "s",
+			"schedule_info": []interface{}{map[string]interface{}{"volume_ids": []interface{}{5}}},
+		}
+		for k, v := range extra {
+			cfg[k] = v
+		}
+		return cfg
+	}
+	weekdays := []interface{}{map[string]interface{}{"day": 1}}
+	cases := map[string]struct {
+		config map[string]interface{}
+		ok     bool
+	}{
+		"interval":                     {base(map[string]interface{}{"schedule_type": "Time Interval", "hours": 6}), true},
+		"interval without interval":    {base(map[string]interface{}{"schedule_type": "Time Interval"}), false},
+		"interval with monthdays":      {base(map[string]interface{}{"schedule_type": "Time Interval", "hours": 6, "monthdays": []interface{}{1}}), false},
+		"days of week":                 {base(map[string]interface{}{"schedule_type": "Days Of Week", "weekdays": weekdays, "hours": 23}), true},
+		"days of week without days":    {base(map[string]interface{}{"schedule_type": "Days Of Week"}), false},
+		"days of week after midnight":  {base(map[string]interface{}{"schedule_type": "Days Of Week", "weekdays": weekdays, "hours": 24}), false},
+		"days of month":                {base(map[string]interface{}{"schedule_type": "Days Of Month", "monthdays": []interface{}{1, 31}}), true},
+		"days of month with weekdays":  {base(map[string]interface{}{"schedule_type": "Days Of Month", "monthdays": []interface{}{1}, "weekdays": weekdays}), false},
+		"frequency attribute":          {base(map[string]interface{}{"schedule_type": "Time Interval", "hours": 1, "attributes": map[string]interface{}{"frequency": "Time Interval"}}), false},
+		"legacy snapshot type":         {base(map[string]interface{}{"schedule_type": "Snapshot", "hours": 1}), false},
+		"day of month out of range":    {base(map[string]interface{}{"schedule_type": "Days Of Month", "monthdays": []interface{}{32}}), false},
+		"minutes out of range":         {base(map[string]interface{}{"schedule_type": "Time Interval", "minutes": 60}), false},
+		"weekday out of range":         {base(map[string]interface{}{"schedule_type": "Days Of Week", "weekdays": []interface{}{map[string]interface{}{"day": 7}}}), false},
+		"starting date not RFC 3339":   {base(map[string]interface{}{"schedule_type": "Time Interval", "hours": 1, "starting_date": "2026-10-01"}), false},
+		"starting date":                {base(map[string]interface{}{"schedule_type": "Time Interval", "hours": 1, "starting_date": "2026-10-01T00:00:00Z"}), true},
+		"days of week with offset two": {base(map[string]interface{}{"schedule_type": "Days Of Week", "weekdays": []interface{}{map[string]interface{}{"day": 0, "offset": 2}}}), true},
+	}
+	r := resourceElementswSchedule()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := terraform.NewResourceConfigRaw(tc.config)
+			diags := r.Validate(cfg)
+			var err error
+			if !diags.HasError() {
+				_, err = r.Diff(context.Background(), nil, cfg, nil)
+			}
+			if failed := diags.HasError() || err != nil; failed == tc.ok {
+				t.Errorf("ok = %v, want %v (%v %v)", !failed, tc.ok, diags, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package solidfire
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceElementSwSnapMirrorEndpoint manages the connection from the Element cluster to an
+// ONTAP cluster it replicates volumes with
+func resourceElementSwSnapMirrorEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElementSwSnapMirrorEndpointCreate,
+		Read:   resourceElementSwSnapMirrorEndpointRead,
+		Update: resourceElementSwSnapMirrorEndpointUpdate,
+		Delete: resourceElementSwSnapMirrorEndpointDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: func(_ context.Context, _ *schema.ResourceDiff, meta interface{}) error {
+			client, ok := meta.(*Client)
+			if !ok {
+				return nil
+			}
+			return client.requireAPIFeature(featureSnapMirror, "solidfire_snapmirror_endpoint")
+		},
+		Schema: map[string]*schema.Schema{
+			"management_ip": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Management IP address of the ONTAP cluster.",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ONTAP cluster administrator.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the ONTAP cluster administrator. It cannot be read back, so changes made outside Terraform are not detected.",
+			},
+			"snapmirror_endpoint_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the endpoint. Use it in solidfire_snapmirror_relationship and the SnapMirror data sources.",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the ONTAP cluster.",
+			},
+			"ip_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Intercluster interface addresses of the ONTAP cluster.",
+			},
+			"is_connected": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the Element cluster can reach the ONTAP cluster.",
+			},
+		},
+	}
+}
+
+func resourceElementSwSnapMirrorEndpointCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	endpoint, err := client.CreateSnapMirrorEndpoint(map[string]interface{}{
+		"managementIP": d.Get("management_ip").(string),
+		"username":     d.Get("username").(string),
+		"password":     d.Get("password").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("CreateSnapMirrorEndpoint failed: %w", err)
+	}
+	d.SetId(strconv.FormatInt(endpoint.SnapMirrorEndpointID, 10))
+
+	return resourceElementSwSnapMirrorEndpointRead(d, meta)
+}
+
+func resourceElementSwSnapMirrorEndpointRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid SnapMirror endpoint ID %q: %w", d.Id(), err)
+	}
+
+	endpoint, err := client.GetSnapMirrorEndpoint(id)
+	if err != nil {
+		return err
+	}
+	if endpoint == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("snapmirror_endpoint_id", int(endpoint.SnapMirrorEndpointID))
+	d.Set("management_ip", endpoint.ManagementIP)
+	d.Set("username", endpoint.Username)
+	d.Set("cluster_name", endpoint.ClusterName)
+	d.Set("is_connected", endpoint.IsConnected)
+	if err := d.Set("ip_addresses", endpoint.IPAddresses); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceElementSwSnapMirrorEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	params := map[string]interface{}{
+		"snapMirrorEndpointID": id,
+	}
+	if d.HasChange("management_ip") {
+		params["managementIP"] = d.Get("management_ip").(string)
+	}
+	if d.HasChange("username") {
+		params["username"] = d.Get("username").(string)
+	}
+	if d.HasChange("password") {
+		params["password"] = d.Get("password").(string)
+	}
+
+	if err := client.ModifySnapMirrorEndpoint(params); err != nil {
+		return fmt.Errorf("ModifySnapMirrorEndpoint failed: %w", err)
+	}
+
+	return resourceElementSwSnapMirrorEndpointRead(d, meta)
+}
+
+func resourceElementSwSnapMirrorEndpointDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	if err := client.DeleteSnapMirrorEndpoint(id); err != nil {
+		return fmt.Errorf("DeleteSnapMirrorEndpoints failed: %w", err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,96 @@
+package solidfire
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+// fakeSnapMirrorEndpoints keeps SnapMirror endpoints by ID; the returned map is the store
+func fakeSnapMirrorEndpoints(api *fakeAPI) map[int64]map[string]interface{} {
+	endpoints := map[int64]map[string]interface{}{}
+	nextID := int64(1)
+	api.handle("CreateSnapMirrorEndpoint", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		e := map[string]interface{}{
+			"snapMirrorEndpointID": nextID,
+			"managementIP":         p["managementIP"],
+			"username":             p["username"],
+			"clusterName":          "ontap1",
+			"ipAddresses":          []string{"10.0.0.11", "10.0.0.12"},
+			"isConnected":          true,
+		}
+		endpoints[nextID] = e
+		nextID++
+		return map[string]interface{}{"snapMirrorEndpoint": e}, nil
+	})
+	api.handle("ListSnapMirrorEndpoints", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		var list []interface{}
+		for _, id := range p["snapMirrorEndpointIDs"].([]interface{}) {
+			if e, ok := endpoints[int64(id.(float64))]; ok {
+				list = append(list, e)
+			}
+		}
+		return map[string]interface{}{"snapMirrorEndpoints": list}, nil
+	})
+	api.handle("ModifySnapMirrorEndpoint", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		e, ok := endpoints[int64(p["snapMirrorEndpointID"].(float64))]
+		if !ok {
+			return nil, &sdk.SdkError{Code: "xSnapMirrorEndpointDoesNotExist"}
+		}
+		for _, k := range []string{"managementIP", "username"} {
+			if v, ok := p[k]; ok {
+				e[k] = v
+			}
+		}
+		return map[string]interface{}{"snapMirrorEndpoint": e}, nil
+	})
+	api.handle("DeleteSnapMirrorEndpoints", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		for _, id := range p["snapMirrorEndpointIDs"].([]interface{}) {
+			delete(endpoints, int64(id.(float64)))
+		}
+		return map[string]interface{}{}, nil
+	})
+	return endpoints
+}
+
+func TestSnapMirrorEndpointLifecycle(t *testing.T) {
+	api := newFakeAPI()
+	endpoints := fakeSnapMirrorEndpoints(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementSwSnapMirrorEndpoint()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"management_ip": "10.0.0.10",
+		"username":      "admin",
+		"password":      "secret",
+	})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "1" || d.Get("cluster_name") != "ontap1" || !d.Get("is_connected").(bool) || d.Get("ip_addresses.#") != 2 {
+		t.Fatalf("unexpected state after create: id %q, %v", d.Id(), d.State().Attributes)
+	}
+
+	endpoints[1]["managementIP"] = "10.0.0.20"
+	if err := r.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("management_ip"); got != "10.0.0.20" {
+		t.Errorf("management_ip = %v, want the changed 10.0.0.20", got)
+	}
+
+	if err := r.Delete(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoints) != 0 {
+		t.Errorf("endpoint not deleted: %v", endpoints)
+	}
+	d.SetId("1")
+	if err := r.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "" {
+		t.Errorf("reading a deleted endpoint should clear the ID, got %q", d.Id())
+	}
+}
@@ -0,0 +1,439 @@
+package solidfire
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	snapMirrorMirrored = "mirrored"
+	snapMirrorQuiesced = "quiesced"
+	snapMirrorBroken   = "broken"
+)
+
+func snapMirrorVolumeSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringInSlice([]string{"solidfire", "ontap"}, false),
+					Description:  "`solidfire` for an Element volume, `ontap` for an ONTAP volume.",
+				},
+				"volume_id": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Description: "ID of the Element volume. Required for type `solidfire`.",
+				},
+				"vserver": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Description: "SVM of the volume. Required for type `ontap`.",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Description: "Name of the volume. Required for type `ontap`.",
+				},
+			},
+		},
+	}
+}
+
+// resourceElementSwSnapMirrorRelationship manages a SnapMirror relationship between an Element
+// volume and an ONTAP volume, in either direction
+func resourceElementSwSnapMirrorRelationship() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElementSwSnapMirrorRelationshipCreate,
+		Read:   resourceElementSwSnapMirrorRelationshipRead,
+		Update: resourceElementSwSnapMirrorRelationshipUpdate,
+		Delete: resourceElementSwSnapMirrorRelationshipDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceElementSwSnapMirrorRelationshipImport,
+		},
+		CustomizeDiff: func(_ context.Context, _ *schema.ResourceDiff, meta interface{}) error {
+			client, ok := meta.(*Client)
+			if !ok {
+				return nil
+			}
+			return client.requireAPIFeature(featureSnapMirror, "solidfire_snapmirror_relationship")
+		},
+		Schema: map[string]*schema.Schema{
+			"snapmirror_endpoint_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the solidfire_snapmirror_endpoint of the ONTAP cluster.",
+			},
+			"source_volume":      snapMirrorVolumeSchema("The volume replicated from."),
+			"destination_volume": snapMirrorVolumeSchema("The volume replicated to. An ONTAP destination must be a data protection (DP) volume."),
+			"relationship_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Type of the relationship. Element only supports `extended_data_protection`, the default.",
+			},
+			"policy_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ONTAP SnapMirror policy of the relationship, `MirrorLatest` by default.",
+			},
+			"schedule_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ONTAP cron schedule that starts update transfers.",
+			},
+			"max_transfer_rate": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum transfer rate in KB/s. 0 means unlimited.",
+			},
+			"initialize": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Start the baseline transfer after creating the relationship. Setting it later starts the baseline transfer of an uninitialized relationship; unsetting it has no effect.",
+			},
+			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      snapMirrorMirrored,
+				ValidateFunc: validation.StringInSlice([]string{snapMirrorMirrored, snapMirrorQuiesced, snapMirrorBroken}, false),
+				Description:  "`mirrored` replicates, `quiesced` stops further transfers, `broken` makes the destination writable. Going back from `broken` to `mirrored` resyncs the destination.",
+			},
+			"update_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Any change to this value starts an update transfer.",
+			},
+			"relationship_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ONTAP ID of the relationship.",
+			},
+			"mirror_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Mirror state reported by ONTAP: uninitialized, snapmirrored or broken_off.",
+			},
+			"relationship_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status reported by ONTAP, e.g. idle, transferring or quiesced.",
+			},
+			"is_healthy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"unhealthy_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"lag_time_seconds": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "How far the destination lags behind the source.",
+			},
+			"last_transfer_error": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_transfer_end_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// parseSnapMirrorRelationshipID splits "<endpoint ID>:<relationship ID>"
+func parseSnapMirrorRelationshipID(id string) (int64, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return 0, "", fmt.Errorf("invalid SnapMirror relationship ID %q, expected <snapmirror endpoint ID>:<relationship ID>", id)
+	}
+	endpointID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid SnapMirror relationship ID %q: %w", id, err)
+	}
+	return endpointID, parts[1], nil
+}
+
+// resourceElementSwSnapMirrorRelationshipImport imports "<endpoint ID>:<relationship ID>".
+// initialize only matters on create, so imported relationships get its default.
+func resourceElementSwSnapMirrorRelationshipImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseSnapMirrorRelationshipID(d.Id()); err != nil {
+		return nil, err
+	}
+	d.Set("initialize", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandSnapMirrorVolume(v interface{}) snapMirrorVolumeInfo {
+	list := v.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return snapMirrorVolumeInfo{}
+	}
+	m := list[0].(map[string]interface{})
+	return snapMirrorVolumeInfo{
+		Type:     m["type"].(string),
+		VolumeID: int64(m["volume_id"].(int)),
+		Vserver:  m["vserver"].(string),
+		Name:     m["name"].(string),
+	}
+}
+
+func flattenSnapMirrorVolume(v snapMirrorVolumeInfo) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"type":      v.Type,
+		"volume_id": int(v.VolumeID),
+		"vserver":   v.Vserver,
+		"name":      v.Name,
+	}}
+}
+
+// snapMirrorState maps a relationship to the state attribute. Uninitialized relationships have
+// no state yet and return "".
+func snapMirrorState(rel *snapMirrorRelationship) string {
+	switch {
+	case rel.MirrorState == "broken_off":
+		return snapMirrorBroken
+	case rel.RelationshipStatus == "quiesced" || rel.RelationshipStatus == "quiescing":
+		return snapMirrorQuiesced
+	case rel.MirrorState == "uninitialized":
+		return ""
+	default:
+		return snapMirrorMirrored
+	}
+}
+
+// snapMirrorStateActions returns the API methods that take a relationship from one state to another
+func snapMirrorStateActions(from, to string) []string {
+	if from == to {
+		return nil
+	}
+	switch from + ">" + to {
+	case snapMirrorMirrored + ">" + snapMirrorQuiesced:
+		return []string{"QuiesceSnapMirrorRelationship"}
+	case snapMirrorMirrored + ">" + snapMirrorBroken:
+		return []string{"QuiesceSnapMirrorRelationship", "BreakSnapMirrorRelationship"}
+	case snapMirrorQuiesced + ">" + snapMirrorMirrored:
+		return []string{"ResumeSnapMirrorRelationship"}
+	case snapMirrorQuiesced + ">" + snapMirrorBroken:
+		return []string{"BreakSnapMirrorRelationship"}
+	case snapMirrorBroken + ">" + snapMirrorMirrored:
+		return []string{"ResyncSnapMirrorRelationship"}
+	case snapMirrorBroken + ">" + snapMirrorQuiesced:
+		return []string{"ResyncSnapMirrorRelationship", "QuiesceSnapMirrorRelationship"}
+	}
+	return nil
+}
+
+func runSnapMirrorActions(client *Client, rel *snapMirrorRelationship, methods []string) error {
+	for _, method := range methods {
+		if err := client.SnapMirrorRelationshipAction(method, rel.SnapMirrorEndpointID, rel.DestinationVolume); err != nil {
+			return fmt.Errorf("%s failed: %w", method, err)
+		}
+	}
+	return nil
+}
+
+func resourceElementSwSnapMirrorRelationshipCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	endpointID := int64(d.Get("snapmirror_endpoint_id").(int))
+
+	params := map[string]interface{}{
+		"snapMirrorEndpointID": endpointID,
+		"sourceVolume":         expandSnapMirrorVolume(d.Get("source_volume")),
+		"destinationVolume":    expandSnapMirrorVolume(d.Get("destination_volume")),
+	}
+	if v, ok := d.GetOk("relationship_type"); ok {
+		params["relationshipType"] = v.(string)
+	}
+	if v, ok := d.GetOk("policy_name"); ok {
+		params["policyName"] = v.(string)
+	}
+	if v, ok := d.GetOk("schedule_name"); ok {
+		params["scheduleName"] = v.(string)
+	}
+	if v, ok := d.GetOk("max_transfer_rate"); ok {
+		params["maxTransferRate"] = v.(int)
+	}
+
+	rel, err := client.CreateSnapMirrorRelationship(params)
+	if err != nil {
+		return fmt.Errorf("CreateSnapMirrorRelationship failed: %w", err)
+	}
+	rel.SnapMirrorEndpointID = endpointID
+	if rel.DestinationVolume.Type == "" {
+		rel.DestinationVolume = params["destinationVolume"].(snapMirrorVolumeInfo)
+	}
+	d.SetId(fmt.Sprintf("%d:%s", endpointID, rel.RelationshipID))
+
+	if d.Get("initialize").(bool) {
+		if err := runSnapMirrorActions(client, rel, []string{"InitializeSnapMirrorRelationship"}); err != nil {
+			return err
+		}
+	}
+	if err := runSnapMirrorActions(client, rel, snapMirrorStateActions(snapMirrorMirrored, d.Get("state").(string))); err != nil {
+		return err
+	}
+
+	return resourceElementSwSnapMirrorRelationshipRead(d, meta)
+}
+
+func resourceElementSwSnapMirrorRelationshipRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	endpointID, relationshipID, err := parseSnapMirrorRelationshipID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	rel, err := client.GetSnapMirrorRelationship(endpointID, relationshipID)
+	if err != nil {
+		return err
+	}
+	if rel == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("snapmirror_endpoint_id", int(endpointID))
+	d.Set("relationship_id", rel.RelationshipID)
+	d.Set("relationship_type", rel.RelationshipType)
+	d.Set("policy_name", rel.PolicyName)
+	d.Set("schedule_name", rel.ScheduleName)
+	d.Set("max_transfer_rate", int(rel.MaxTransferRate))
+	d.Set("mirror_state", rel.MirrorState)
+	d.Set("relationship_status", rel.RelationshipStatus)
+	d.Set("is_healthy", rel.IsHealthy)
+	d.Set("unhealthy_reason", rel.UnhealthyReason)
+	d.Set("lag_time_seconds", int(rel.Lagtime))
+	d.Set("last_transfer_error", rel.LastTransferError)
+	d.Set("last_transfer_end_time", rel.LastTransferEndTimestamp)
+	if state := snapMirrorState(rel); state != "" {
+		d.Set("state", state)
+	}
+	if err := d.Set("source_volume", flattenSnapMirrorVolume(rel.SourceVolume)); err != nil {
+		return err
+	}
+	if err := d.Set("destination_volume", flattenSnapMirrorVolume(rel.DestinationVolume)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceElementSwSnapMirrorRelationshipUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	endpointID, relationshipID, err := parseSnapMirrorRelationshipID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	rel, err := client.GetSnapMirrorRelationship(endpointID, relationshipID)
+	if err != nil {
+		return err
+	}
+	if rel == nil {
+		return fmt.Errorf("SnapMirror relationship %s not found", relationshipID)
+	}
+	rel.SnapMirrorEndpointID = endpointID
+
+	if d.HasChanges("policy_name", "schedule_name", "max_transfer_rate") {
+		params := map[string]interface{}{
+			"snapMirrorEndpointID": endpointID,
+			"destinationVolume":    rel.DestinationVolume,
+		}
+		if d.HasChange("policy_name") {
+			params["policyName"] = d.Get("policy_name").(string)
+		}
+		if d.HasChange("schedule_name") {
+			params["scheduleName"] = d.Get("schedule_name").(string)
+		}
+		if d.HasChange("max_transfer_rate") {
+			params["maxTransferRate"] = d.Get("max_transfer_rate").(int)
+		}
+		if err := client.ModifySnapMirrorRelationship(params); err != nil {
+			return fmt.Errorf("ModifySnapMirrorRelationship failed: %w", err)
+		}
+	}
+
+	if d.HasChange("initialize") && d.Get("initialize").(bool) && rel.MirrorState == "uninitialized" {
+		if err := runSnapMirrorActions(client, rel, []string{"InitializeSnapMirrorRelationship"}); err != nil {
+			return err
+		}
+		rel.MirrorState = "snapmirrored"
+	}
+
+	if d.HasChange("state") {
+		from := snapMirrorState(rel)
+		if from == "" {
+			from = snapMirrorMirrored
+		}
+		if err := runSnapMirrorActions(client, rel, snapMirrorStateActions(from, d.Get("state").(string))); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("update_trigger") && d.Get("state").(string) == snapMirrorMirrored {
+		if err := runSnapMirrorActions(client, rel, []string{"UpdateSnapMirrorRelationship"}); err != nil {
+			return err
+		}
+	}
+
+	return resourceElementSwSnapMirrorRelationshipRead(d, meta)
+}
+
+// resourceElementSwSnapMirrorRelationshipDelete quiesces a mirrored relationship and deletes it.
+// The destination volume is kept, and stays read-only unless the relationship was broken.
+func resourceElementSwSnapMirrorRelationshipDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	endpointID, relationshipID, err := parseSnapMirrorRelationshipID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	rel, err := client.GetSnapMirrorRelationship(endpointID, relationshipID)
+	if err != nil {
+		return err
+	}
+	if rel == nil {
+		d.SetId("")
+		return nil
+	}
+	rel.SnapMirrorEndpointID = endpointID
+
+	if snapMirrorState(rel) == snapMirrorMirrored {
+		if err := runSnapMirrorActions(client, rel, []string{"QuiesceSnapMirrorRelationship"}); err != nil {
+			return err
+		}
+	}
+	if err := client.DeleteSnapMirrorRelationship(endpointID, rel.DestinationVolume); err != nil {
+		return fmt.Errorf("DeleteSnapMirrorRelationships failed: %w", err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,245 @@
+package solidfire
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+// fakeSnapMirrorRelationships serves the SnapMirror relationship API methods, moving the
+// relationship through its ONTAP mirror states
+func fakeSnapMirrorRelationships(api *fakeAPI) map[string]map[string]interface{} {
+	rels := map[string]map[string]interface{}{}
+	byDestination := func(p map[string]interface{}) (map[string]interface{}, *sdk.SdkError) {
+		dest := p["destinationVolume"].(map[string]interface{})
+		for _, r := range rels {
+			if reflect.DeepEqual(r["destinationVolume"], dest) {
+				return r, nil
+			}
+		}
+		return nil, &sdk.SdkError{Code: "xSnapMirrorRelationshipDoesNotExist", Detail: "no relationship to that destination"}
+	}
+	transition := func(method, mirrorState, status string) {
+		api.handle(method, func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+			r, err := byDestination(p)
+			if err != nil {
+				return nil, err
+			}
+			if mirrorState != "" {
+				r["mirrorState"] = mirrorState
+			}
+			r["relationshipStatus"] = status
+			return map[string]interface{}{"snapMirrorRelationship": r}, nil
+		})
+	}
+
+	api.handle("CreateSnapMirrorRelationship", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		r := map[string]interface{}{
+			"snapMirrorEndpointID": p["snapMirrorEndpointID"],
+			"relationshipID":       "5d4b-rel",
+			"sourceVolume":         p["sourceVolume"],
+			"destinationVolume":    p["destinationVolume"],
+			"relationshipType":     "extended_data_protection",
+			"policyName":           "MirrorLatest",
+			"scheduleName":         p["scheduleName"],
+			"mirrorState":          "uninitialized",
+			"relationshipStatus":   "idle",
+			"isHealthy":            true,
+		}
+		rels["5d4b-rel"] = r
+		return map[string]interface{}{"snapMirrorRelationship": r}, nil
+	})
+	api.handle("ListSnapMirrorRelationships", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		var list []interface{}
+		if r, ok := rels[p["relationshipID"].(string)]; ok {
+			list = append(list, r)
+		}
+		return map[string]interface{}{"snapMirrorRelationships": list}, nil
+	})
+	api.handle("ModifySnapMirrorRelationship", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		r, err := byDestination(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range []string{"policyName", "scheduleName", "maxTransferRate"} {
+			if v, ok := p[k]; ok {
+				r[k] = v
+			}
+		}
+		return map[string]interface{}{"snapMirrorRelationship": r}, nil
+	})
+	api.handle("DeleteSnapMirrorRelationships", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		for _, dest := range p["destinationVolumes"].([]interface{}) {
+			r, err := byDestination(map[string]interface{}{"destinationVolume": dest})
+			if err != nil {
+				return nil, err
+			}
+			delete(rels, r["relationshipID"].(string))
+		}
+		return map[string]interface{}{}, nil
+	})
+	transition("InitializeSnapMirrorRelationship", "snapmirrored", "transferring")
+	transition("UpdateSnapMirrorRelationship", "", "transferring")
+	transition("QuiesceSnapMirrorRelationship", "", "quiesced")
+	transition("ResumeSnapMirrorRelationship", "", "idle")
+	transition("BreakSnapMirrorRelationship", "broken_off", "idle")
+	transition("ResyncSnapMirrorRelationship", "snapmirrored", "transferring")
+	return rels
+}
+
+func TestSnapMirrorRelationshipLifecycle(t *testing.T) {
+	api := newFakeAPI()
+	rels := fakeSnapMirrorRelationships(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementSwSnapMirrorRelationship()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"snapmirror_endpoint_id": 1,
+		"source_volume": []interface{}{map[string]interface{}{
+			"type":      "solidfire",
+			"volume_id": 42,
+		}},
+		"destination_volume": []interface{}{map[string]interface{}{
+			"type":    "ontap",
+			"vserver": "svm1",
+			"name":    "vol42_dp",
+		}},
+		"schedule_name": "hourly",
+	})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "1:5d4b-rel" {
+		t.Errorf("ID = %q, want 1:5d4b-rel", d.Id())
+	}
+	if got := d.Get("mirror_state"); got != "snapmirrored" {
+		t.Errorf("mirror_state = %v, want snapmirrored after initialize", got)
+	}
+	if got := d.Get("destination_volume.0.name"); got != "vol42_dp" {
+		t.Errorf("destination_volume.0.name = %v", got)
+	}
+
+	// Drift made outside Terraform shows up in state
+	rels["5d4b-rel"]["mirrorState"] = "broken_off"
+	if err := r.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("state"); got != snapMirrorBroken {
+		t.Errorf("state = %v, want broken", got)
+	}
+
+	// An imported relationship matches a configuration using the default initialize
+	imported := r.TestResourceData()
+	imported.SetId(d.Id())
+	res, err := r.Importer.State(imported, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Read(res[0], client); err != nil {
+		t.Fatal(err)
+	}
+	diff, err := r.Diff(context.Background(), res[0].State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"snapmirror_endpoint_id": 1,
+		"source_volume":          []interface{}{map[string]interface{}{"type": "solidfire", "volume_id": 42}},
+		"destination_volume":     []interface{}{map[string]interface{}{"type": "ontap", "vserver": "svm1", "name": "vol42_dp"}},
+		"schedule_name":          "hourly",
+		"state":                  snapMirrorBroken,
+	}), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("unexpected diff after import: %v", diff.Attributes)
+	}
+
+	if err := r.Delete(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 0 {
+		t.Errorf("relationship not deleted: %v", rels)
+	}
+	want := []string{
+		"CreateSnapMirrorRelationship", "InitializeSnapMirrorRelationship", "ListSnapMirrorRelationships",
+		"ListSnapMirrorRelationships",
+		"ListSnapMirrorRelationships",
+		"ListSnapMirrorRelationships", "DeleteSnapMirrorRelationships",
+	}
+	if got := api.called(); !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v\nwant %v", got, want)
+	}
+}
+
+func TestSnapMirrorRelationshipCreateBroken(t *testing.T) {
+	api := newFakeAPI()
+	fakeSnapMirrorRelationships(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementSwSnapMirrorRelationship()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"snapmirror_endpoint_id": 1,
+		"source_volume": []interface{}{map[string]interface{}{
+			"type":    "ontap",
+			"vserver": "svm1",
+			"name":    "vol7",
+		}},
+		"destination_volume": []interface{}{map[string]interface{}{
+			"type":      "solidfire",
+			"volume_id": 7,
+		}},
+		"state": snapMirrorBroken,
+	})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("state"); got != snapMirrorBroken {
+		t.Errorf("state = %v, want broken", got)
+	}
+
+	// A broken relationship is deleted without quiescing it first
+	if err := r.Delete(d, client); err != nil {
+		t.Fatal(err)
+	}
+	calls := api.called()
+	want := []string{
+		"CreateSnapMirrorRelationship", "InitializeSnapMirrorRelationship",
+		"QuiesceSnapMirrorRelationship", "BreakSnapMirrorRelationship", "ListSnapMirrorRelationships",
+		"ListSnapMirrorRelationships", "DeleteSnapMirrorRelationships",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v\nwant %v", calls, want)
+	}
+}
+
+func TestSnapMirrorStateActions(t *testing.T) {
+	cases := []struct {
+		from, to string
+		want     []string
+	}{
+		{snapMirrorMirrored, snapMirrorMirrored, nil},
+		{snapMirrorMirrored, snapMirrorQuiesced, []string{"QuiesceSnapMirrorRelationship"}},
+		{snapMirrorMirrored, snapMirrorBroken, []string{"QuiesceSnapMirrorRelationship", "BreakSnapMirrorRelationship"}},
+		{snapMirrorQuiesced, snapMirrorMirrored, []string{"ResumeSnapMirrorRelationship"}},
+		{snapMirrorBroken, snapMirrorMirrored, []string{"ResyncSnapMirrorRelationship"}},
+	}
+	for _, c := range cases {
+		if got := snapMirrorStateActions(c.from, c.to); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s -> %s: got %v, want %v", c.from, c.to, got, c.want)
+		}
+	}
+}
+
+func TestParseSnapMirrorRelationshipID(t *testing.T) {
+	endpointID, relID, err := parseSnapMirrorRelationshipID("3:ab:cd")
+	if err != nil || endpointID != 3 || relID != "ab:cd" {
+		t.Errorf("got %d, %q, %v", endpointID, relID, err)
+	}
+	for _, id := range []string{"3", "x:abc", "3:"} {
+		if _, _, err := parseSnapMirrorRelationshipID(id); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
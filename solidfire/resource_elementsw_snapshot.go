@@ -2,237 +2,354 @@ package solidfire
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/scaleoutsean/solidfire-go/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// resourceElementswSnapshot returns the Terraform resource for SolidFire snapshots (individual or group)
+var retentionRegexp = regexp.MustCompile(`^\d+:[0-5]\d:[0-5]\d$`)
+
+// resourceElementswSnapshot returns the Terraform resource for SolidFire snapshots (individual or group).
+// Snapshots have IDs "snap-<snapshotID>" and group snapshots "group-<groupSnapshotID>".
 func resourceElementswSnapshot() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceElementswSnapshotCreate,
 		Read:   resourceElementswSnapshotRead,
 		Update: resourceElementswSnapshotUpdate,
 		Delete: resourceElementswSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceElementswSnapshotImport,
+		},
+		CustomizeDiff: requireAPIFeatures(map[string]apiFeature{
+			"snapmirror_label": featureSnapMirror,
+		}),
 		Schema: map[string]*schema.Schema{
 			"volume_id": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"volume_id", "volume_ids"},
+				Description:  "Volume to snapshot.",
 			},
 			"volume_ids": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				Elem:         &schema.Schema{Type: schema.TypeInt},
+				ExactlyOneOf: []string{"volume_id", "volume_ids"},
+				Description:  "Volumes to take a crash-consistent group snapshot of.",
 			},
 			"snapshot_id": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Deprecated:  "Has no effect. Use created_snapshot_id to refer to the snapshot.",
+				Description: "Has no effect.",
 			},
 			"group_snapshot_id": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Deprecated:  "Has no effect. Use created_group_snapshot_id to refer to the group snapshot.",
+				Description: "Has no effect.",
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Name of the snapshot. Defaults to the creation time. Element cannot rename snapshots, so changing it replaces the snapshot.",
 			},
 			"snapmirror_label": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Label SnapMirror policies use to select the snapshot for replication to ONTAP.",
 			},
 			"enable_remote_replication": {
-				Type:     schema.TypeBool,
-				Optional: true,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Replicate the snapshot to the paired volumes.",
 			},
 			"ensure_serial_creation": {
-				Type:     schema.TypeBool,
-				Optional: true,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Fail the creation while a previous snapshot is still being replicated. Only used when the snapshot is created.",
 			},
 			"retention": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringMatch(retentionRegexp, "must be HH:mm:ss"),
+				ConflictsWith: []string{"expiration_time"},
+				Description:   "How long to keep the snapshot after its creation, as HH:mm:ss. Changing it moves the expiration time.",
 			},
 			"expiration_time": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"retention"},
+				Description:   "When the snapshot is deleted, as an RFC 3339 time. Computed from `retention` when that is set; empty for snapshots that are kept until deleted.",
 			},
 			"attributes": {
-				Type:     schema.TypeMap,
-				Optional: true,
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Attributes of the snapshot. Element cannot change them, so changing them replaces the snapshot.",
 			},
 			"save_members": {
-				Type:     schema.TypeBool,
-				Optional: true,
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"volume_id"},
+				Description:   "Keep the member snapshots when the group snapshot is deleted.",
 			},
 			// Output fields
 			"created_snapshot_id": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the snapshot.",
 			},
 			"created_group_snapshot_id": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the group snapshot.",
 			},
 			"create_time": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the snapshot was taken.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the snapshot, e.g. `done`.",
+			},
+			"remote_statuses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Replication state of the snapshot on each paired volume.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"volume_pair_uuid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "UUID of the volume pair.",
+						},
+						"remote_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the snapshot on the remote volume: `Present`, `NotPresent`, `Syncing`, `Deleted` or `Unknown`.",
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
+// snapshotCreateParams returns the parameters CreateSnapshot and CreateGroupSnapshot share
+func snapshotCreateParams(d *schema.ResourceData) map[string]interface{} {
+	params := map[string]interface{}{}
+	if v, ok := d.GetOk("name"); ok {
+		params["name"] = v.(string)
+	}
+	if v, ok := d.GetOk("enable_remote_replication"); ok {
+		params["enableRemoteReplication"] = v.(bool)
+	}
+	if v, ok := d.GetOk("ensure_serial_creation"); ok {
+		params["ensureSerialCreation"] = v.(bool)
+	}
+	if v, ok := d.GetOk("retention"); ok {
+		params["retention"] = v.(string)
+	}
+	if v, ok := d.GetOk("expiration_time"); ok {
+		params["expirationTime"] = v.(string)
+	}
+	if v, ok := d.GetOk("snapmirror_label"); ok {
+		params["snapMirrorLabel"] = v.(string)
+	}
+	if v, ok := d.GetOk("attributes"); ok {
+		params["attributes"] = v.(map[string]interface{})
+	}
+	return params
+}
+
 func resourceElementswSnapshotCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	// If group_snapshot, use CreateGroupSnapshot
-	if _, ok := d.GetOk("volume_ids"); ok {
-		// Create group snapshot
-		volumes := []int64{}
-		if v, ok := d.GetOk("volume_ids"); ok {
-			for _, id := range v.([]interface{}) {
-				volumes = append(volumes, int64(id.(int)))
-			}
-		}
-		req := sdk.CreateGroupSnapshotRequest{
-			Volumes: volumes,
-			Name:    d.Get("name").(string),
-		}
-		if v, ok := d.GetOk("enable_remote_replication"); ok {
-			req.EnableRemoteReplication = v.(bool)
-		}
-		if v, ok := d.GetOk("retention"); ok {
-			req.Retention = v.(string)
-		}
-		// ExpirationTime and EnsureSerialCreation are not in CreateGroupSnapshotRequest in this SDK version
-		resp, err := client.CreateGroupSnapshot(&req)
+	params := snapshotCreateParams(d)
+
+	if v, ok := d.GetOk("volume_ids"); ok {
+		params["volumes"] = toInt64Slice(v.(*schema.Set).List())
+		id, err := client.CreateGroupSnapshot(params)
 		if err != nil {
-			return err
+			return fmt.Errorf("CreateGroupSnapshot failed: %w", err)
 		}
-		d.SetId(fmt.Sprintf("group-%d", resp.GroupSnapshotID))
-		d.Set("created_group_snapshot_id", int(resp.GroupSnapshotID))
+		d.SetId(fmt.Sprintf("group-%d", id))
 		return resourceElementswSnapshotRead(d, m)
 	}
-	// Otherwise, create individual snapshot
-	req := sdk.CreateSnapshotRequest{
-		VolumeID:        int64(d.Get("volume_id").(int)),
-		Name:            d.Get("name").(string),
-		SnapMirrorLabel: d.Get("snapmirror_label").(string),
-	}
-	if v, ok := d.GetOk("enable_remote_replication"); ok {
-		req.EnableRemoteReplication = v.(bool)
-	}
-	if v, ok := d.GetOk("retention"); ok {
-		req.Retention = v.(string)
-	}
-	// ExpirationTime and EnsureSerialCreation are not in CreateSnapshotRequest in this SDK version
-	resp, err := client.CreateSnapshot(&req)
+
+	params["volumeID"] = int64(d.Get("volume_id").(int))
+	id, err := client.CreateSnapshot(params)
 	if err != nil {
-		return err
+		return fmt.Errorf("CreateSnapshot failed: %w", err)
 	}
-	d.SetId(fmt.Sprintf("snap-%d", resp.SnapshotID))
-	d.Set("created_snapshot_id", int(resp.SnapshotID))
-	d.Set("create_time", resp.Snapshot.CreateTime)
+	d.SetId(fmt.Sprintf("snap-%d", id))
 	return resourceElementswSnapshotRead(d, m)
 }
 
+// parseSnapshotID splits a resource ID into whether it is a group snapshot and the Element ID
+func parseSnapshotID(id string) (bool, int64, error) {
+	rest, group := strings.CutPrefix(id, "group-")
+	if !group {
+		rest, _ = strings.CutPrefix(id, "snap-")
+	}
+	n, err := strconv.ParseInt(rest, 10, 64)
+	if err != nil || rest == id {
+		return false, 0, fmt.Errorf("invalid snapshot ID %q: expected snap-<snapshotID> or group-<groupSnapshotID>", id)
+	}
+	return group, n, nil
+}
+
 func resourceElementswSnapshotUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	idStr := d.Id()
-	if strings.HasPrefix(idStr, "group-") {
-		id, _ := strconv.ParseInt(strings.TrimPrefix(idStr, "group-"), 10, 64)
-		req := sdk.ModifyGroupSnapshotRequest{
-			GroupSnapshotID: id,
-		}
-		if v, ok := d.GetOk("enable_remote_replication"); ok {
-			req.EnableRemoteReplication = v.(bool)
-		}
-		if v, ok := d.GetOk("expiration_time"); ok {
-			req.ExpirationTime = v.(string)
-		}
-		if v, ok := d.GetOk("snapmirror_label"); ok {
-			req.SnapMirrorLabel = v.(string)
-		}
-		// Note: Name is not in ModifyGroupSnapshotRequest in this SDK version
-		return client.ModifyGroupSnapshot(&req)
-	} else if strings.HasPrefix(idStr, "snap-") {
-		id, _ := strconv.ParseInt(strings.TrimPrefix(idStr, "snap-"), 10, 64)
-		req := sdk.ModifySnapshotRequest{
-			SnapshotID: id,
-		}
-		if v, ok := d.GetOk("enable_remote_replication"); ok {
-			req.EnableRemoteReplication = v.(bool)
+	group, id, err := parseSnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	params := map[string]interface{}{}
+	if d.HasChange("enable_remote_replication") {
+		params["enableRemoteReplication"] = d.Get("enable_remote_replication").(bool)
+	}
+	if d.HasChange("snapmirror_label") {
+		params["snapMirrorLabel"] = d.Get("snapmirror_label").(string)
+	}
+	// Element keeps no retention, only the expiration time it results in
+	if v := d.Get("retention").(string); d.HasChange("retention") && v != "" {
+		expiration, err := snapshotExpiration(d.Get("create_time").(string), v)
+		if err != nil {
+			return err
 		}
-		if v, ok := d.GetOk("expiration_time"); ok {
-			req.ExpirationTime = v.(string)
+		params["expirationTime"] = expiration
+	} else if v := d.Get("expiration_time").(string); d.HasChange("expiration_time") && v != "" {
+		params["expirationTime"] = v
+	}
+	// ensure_serial_creation and save_members only change what the provider does
+	if len(params) == 0 {
+		return resourceElementswSnapshotRead(d, m)
+	}
+
+	if group {
+		params["groupSnapshotID"] = id
+		if err := client.ModifyGroupSnapshot(params); err != nil {
+			return fmt.Errorf("ModifyGroupSnapshot failed: %w", err)
 		}
-		if v, ok := d.GetOk("snapmirror_label"); ok {
-			req.SnapMirrorLabel = v.(string)
+	} else {
+		params["snapshotID"] = id
+		if err := client.ModifySnapshot(params); err != nil {
+			return fmt.Errorf("ModifySnapshot failed: %w", err)
 		}
-		// Note: Name is not in ModifySnapshotRequest in this SDK version
-		return client.ModifySnapshot(&req)
 	}
-	return nil
+	return resourceElementswSnapshotRead(d, m)
 }
 
 func resourceElementswSnapshotDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	idStr := d.Id()
-	if strings.HasPrefix(idStr, "group-") {
-		id, _ := strconv.ParseInt(strings.TrimPrefix(idStr, "group-"), 10, 64)
-		saveMembers := false
-		if v, ok := d.GetOk("save_members"); ok {
-			saveMembers = v.(bool)
+	group, id, err := parseSnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+	if group {
+		if err := client.DeleteGroupSnapshot(id, d.Get("save_members").(bool)); err != nil {
+			return fmt.Errorf("DeleteGroupSnapshot failed: %w", err)
 		}
-		return client.DeleteGroupSnapshot(id, saveMembers)
-	} else if strings.HasPrefix(idStr, "snap-") {
-		id, _ := strconv.ParseInt(strings.TrimPrefix(idStr, "snap-"), 10, 64)
-		return client.DeleteSnapshot(id)
+		return nil
+	}
+	if err := client.DeleteSnapshot(id); err != nil {
+		return fmt.Errorf("DeleteSnapshot failed: %w", err)
 	}
 	return nil
 }
 
 func resourceElementswSnapshotRead(d *schema.ResourceData, m interface{}) error {
 	client := m.(*Client)
-	idStr := d.Id()
-
-	if strings.HasPrefix(idStr, "group-") {
-		id, _ := strconv.ParseInt(strings.TrimPrefix(idStr, "group-"), 10, 64)
-		vols := []int64{}
-		if v, ok := d.GetOk("volume_ids"); ok {
-			for _, vid := range v.([]interface{}) {
-				vols = append(vols, int64(vid.(int)))
-			}
-		}
-		res, err := client.ListGroupSnapshots(vols)
+	group, id, err := parseSnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if group {
+		gs, err := client.GetGroupSnapshot(id)
 		if err != nil {
-			return err
+			return fmt.Errorf("ListGroupSnapshots failed: %w", err)
 		}
-		for _, gs := range res {
-			if gs.GroupSnapshotID == id {
-				d.Set("name", gs.Name)
-				d.Set("create_time", gs.CreateTime)
-				return nil
-			}
+		if gs == nil {
+			d.SetId("")
+			return nil
 		}
-		d.SetId("") // Not found
-	} else if strings.HasPrefix(idStr, "snap-") {
-		id, _ := strconv.ParseInt(strings.TrimPrefix(idStr, "snap-"), 10, 64)
-		volID := int64(d.Get("volume_id").(int))
-		res, err := client.ListSnapshots(volID)
-		if err != nil {
+		volumeIDs := make([]interface{}, 0, len(gs.Members))
+		for _, s := range gs.Members {
+			volumeIDs = append(volumeIDs, int(s.VolumeID))
+		}
+		if err := d.Set("volume_ids", volumeIDs); err != nil {
 			return err
 		}
-		for _, s := range res {
-			if s.SnapshotID == id {
-				d.Set("name", s.Name)
-				d.Set("create_time", s.CreateTime)
-				d.Set("snapmirror_label", s.SnapMirrorLabel)
-				return nil
-			}
+		d.Set("created_group_snapshot_id", int(gs.GroupSnapshotID))
+		d.Set("name", gs.Name)
+		d.Set("create_time", gs.CreateTime)
+		d.Set("status", gs.Status)
+		d.Set("enable_remote_replication", gs.EnableRemoteReplication)
+		// expiration and label are kept on the member snapshots, which share them
+		if len(gs.Members) > 0 {
+			d.Set("expiration_time", gs.Members[0].ExpirationTime)
+			d.Set("snapmirror_label", gs.Members[0].SnapMirrorLabel)
 		}
-		d.SetId("") // Not found
+		return setSnapshotCommon(d, gs.Attributes, gs.RemoteStatuses)
 	}
 
-	return nil
+	s, err := client.GetSnapshot(id)
+	if err != nil {
+		return fmt.Errorf("ListSnapshots failed: %w", err)
+	}
+	if s == nil {
+		d.SetId("")
+		return nil
+	}
+	d.Set("volume_id", int(s.VolumeID))
+	d.Set("created_snapshot_id", int(s.SnapshotID))
+	d.Set("name", s.Name)
+	d.Set("create_time", s.CreateTime)
+	d.Set("status", s.Status)
+	d.Set("enable_remote_replication", s.EnableRemoteReplication)
+	d.Set("expiration_time", s.ExpirationTime)
+	d.Set("snapmirror_label", s.SnapMirrorLabel)
+	return setSnapshotCommon(d, s.Attributes, s.RemoteStatuses)
+}
+
+func setSnapshotCommon(d *schema.ResourceData, attributes map[string]interface{}, remoteStatuses []snapshotRemoteStatus) error {
+	if err := d.Set("attributes", flattenAttributes(attributes)); err != nil {
+		return err
+	}
+
+	statuses := make([]interface{}, 0, len(remoteStatuses))
+	for _, rs := range remoteStatuses {
+		statuses = append(statuses, map[string]interface{}{
+			"volume_pair_uuid": rs.VolumePairUUID,
+			"remote_status":    rs.RemoteStatus,
+		})
+	}
+	return d.Set("remote_statuses", statuses)
+}
+
+// resourceElementswSnapshotImport imports snap-<snapshotID> or group-<groupSnapshotID>; a bare
+// number is taken as a snapshot ID
+func resourceElementswSnapshotImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.ParseInt(d.Id(), 10, 64); err == nil {
+		d.SetId("snap-" + d.Id())
+	}
+	if _, _, err := parseSnapshotID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
 }
@@ -1,9 +1,15 @@
 package solidfire
 
 import (
+	"context"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
 func TestAccElementswSnapshot_basic(t *testing.T) {
@@ -20,6 +26,12 @@ func TestAccElementswSnapshot_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "create_time"),
 				),
 			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ensure_serial_creation", "retention", "save_members"},
+			},
 		},
 	})
 }
@@ -80,3 +92,264 @@ resource "solidfire_snapshot" "group" {
 }
 `
 }
+
+// fakeSnapshots holds the snapshots and group snapshots of the fake cluster by ID
+type fakeSnapshots struct {
+	snapshots map[int64]map[string]interface{}
+	groups    map[int64]map[string]interface{}
+}
+
+func newFakeSnapshots(api *fakeAPI) *fakeSnapshots {
+	f := &fakeSnapshots{
+		snapshots: map[int64]map[string]interface{}{},
+		groups:    map[int64]map[string]interface{}{},
+	}
+	nextID := int64(10)
+	newSnapshot := func(volumeID interface{}, p map[string]interface{}) map[string]interface{} {
+		s := map[string]interface{}{
+			"snapshotID":              nextID,
+			"volumeID":                volumeID,
+			"name":                    p["name"],
+			"status":                  "done",
+			"createTime":              "2026-10-01T12:00:00Z",
+			"expirationTime":          p["expirationTime"],
+			"enableRemoteReplication": p["enableRemoteReplication"],
+			"snapMirrorLabel":         p["snapMirrorLabel"],
+			"attributes":              p["attributes"],
+		}
+		if r, ok := p["retention"].(string); ok {
+			s["expirationTime"], _ = snapshotExpiration("2026-10-01T12:00:00Z", r)
+		}
+		f.snapshots[nextID] = s
+		nextID++
+		return s
+	}
+	api.handle("CreateSnapshot", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		s := newSnapshot(p["volumeID"], p)
+		return map[string]interface{}{"snapshotID": s["snapshotID"], "snapshot": s}, nil
+	})
+	api.handle("ListSnapshots", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		s, ok := f.snapshots[int64(p["snapshotID"].(float64))]
+		if !ok {
+			return nil, &sdk.SdkError{Code: "xSnapshotIDDoesNotExist"}
+		}
+		return map[string]interface{}{"snapshots": []interface{}{s}}, nil
+	})
+	api.handle("ModifySnapshot", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		s := f.snapshots[int64(p["snapshotID"].(float64))]
+		for _, k := range []string{"expirationTime", "enableRemoteReplication", "snapMirrorLabel"} {
+			if v, ok := p[k]; ok {
+				s[k] = v
+			}
+		}
+		return map[string]interface{}{"snapshot": s}, nil
+	})
+	api.handle("CreateGroupSnapshot", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		var members []interface{}
+		for _, v := range p["volumes"].([]interface{}) {
+			members = append(members, newSnapshot(v, p))
+		}
+		g := map[string]interface{}{
+			"groupSnapshotID":         nextID,
+			"name":                    p["name"],
+			"status":                  "done",
+			"createTime":              "2026-10-01T12:00:00Z",
+			"enableRemoteReplication": p["enableRemoteReplication"],
+			"members":                 members,
+		}
+		f.groups[nextID] = g
+		nextID++
+		return map[string]interface{}{"groupSnapshotID": g["groupSnapshotID"], "members": members}, nil
+	})
+	api.handle("ListGroupSnapshots", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		g, ok := f.groups[int64(p["groupSnapshotID"].(float64))]
+		if !ok {
+			return map[string]interface{}{"groupSnapshots": []interface{}{}}, nil
+		}
+		return map[string]interface{}{"groupSnapshots": []interface{}{g}}, nil
+	})
+	return f
+}
+
+func TestSnapshotCreateSendsAllParameters(t *testing.T) {
+	api := newFakeAPI()
+	newFakeSnapshots(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementswSnapshot()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"volume_id":                 5,
+		"name":                      "nightly",
+		"expiration_time":           "2026-11-01T00:00:00Z",
+		"ensure_serial_creation":    true,
+		"enable_remote_replication": true,
+		"snapmirror_label":          "daily",
+		"attributes":                map[string]interface{}{"owner": "db"},
+	})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"volumeID":                float64(5),
+		"name":                    "nightly",
+		"expirationTime":          "2026-11-01T00:00:00Z",
+		"ensureSerialCreation":    true,
+		"enableRemoteReplication": true,
+		"snapMirrorLabel":         "daily",
+		"attributes":              map[string]interface{}{"owner": "db"},
+	}
+	if got := api.lastParams("CreateSnapshot"); !reflect.DeepEqual(got, want) {
+		t.Errorf("CreateSnapshot params = %v, want %v", got, want)
+	}
+	if d.Id() != "snap-10" || d.Get("created_snapshot_id") != 10 || d.Get("status") != "done" || d.Get("attributes.owner") != "db" {
+		t.Errorf("unexpected state after create: id %q, %v", d.Id(), d.State().Attributes)
+	}
+}
+
+func TestSnapshotReadDetectsDrift(t *testing.T) {
+	api := newFakeAPI()
+	fake := newFakeSnapshots(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementswSnapshot()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"volume_id": 5,
+		"retention": "24:00:00",
+	})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("expiration_time"); got != "2026-10-02T12:00:00Z" {
+		t.Errorf("expiration_time = %v, want the retention applied to the create time", got)
+	}
+
+	fake.snapshots[10]["expirationTime"] = "2026-10-05T12:00:00Z"
+	fake.snapshots[10]["enableRemoteReplication"] = true
+	fake.snapshots[10]["remoteStatuses"] = []interface{}{
+		map[string]interface{}{"remoteStatus": "Present", "volumePairUUID": "a1b2"},
+	}
+	if err := r.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("expiration_time") != "2026-10-05T12:00:00Z" || !d.Get("enable_remote_replication").(bool) {
+		t.Errorf("changes made outside Terraform not read: %v", d.State().Attributes)
+	}
+	if d.Get("remote_statuses.0.remote_status") != "Present" || d.Get("remote_statuses.0.volume_pair_uuid") != "a1b2" {
+		t.Errorf("remote_statuses = %v", d.Get("remote_statuses"))
+	}
+
+	delete(fake.snapshots, 10)
+	if err := r.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "" {
+		t.Error("a deleted snapshot should be removed from state")
+	}
+}
+
+func TestSnapshotUpdateRetentionMovesExpiration(t *testing.T) {
+	api := newFakeAPI()
+	fake := newFakeSnapshots(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementswSnapshot()
+
+	state := &terraform.InstanceState{
+		ID: "snap-10",
+		Attributes: map[string]string{
+			"id":                        "snap-10",
+			"volume_id":                 "5",
+			"retention":                 "24:00:00",
+			"create_time":               "2026-10-01T12:00:00Z",
+			"enable_remote_replication": "false",
+		},
+	}
+	fake.snapshots[10] = map[string]interface{}{
+		"snapshotID": 10, "volumeID": 5, "createTime": "2026-10-01T12:00:00Z", "expirationTime": "2026-10-02T12:00:00Z",
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"volume_id":                 5,
+		"retention":                 "48:00:00",
+		"enable_remote_replication": true,
+	}), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.RequiresNew() {
+		t.Fatal("changing retention or remote replication should not replace the snapshot")
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Update(d, client); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"snapshotID":              float64(10),
+		"expirationTime":          "2026-10-03T12:00:00Z",
+		"enableRemoteReplication": true,
+	}
+	if got := api.lastParams("ModifySnapshot"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ModifySnapshot params = %v, want %v", got, want)
+	}
+}
+
+func TestGroupSnapshotLifecycle(t *testing.T) {
+	api := newFakeAPI()
+	newFakeSnapshots(api)
+	client := newFakeAPIClient(t, api)
+	r := resourceElementswSnapshot()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"volume_ids":       []interface{}{5, 6},
+		"name":             "consistent",
+		"snapmirror_label": "weekly",
+	})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if got := api.lastParams("CreateGroupSnapshot")["snapMirrorLabel"]; got != "weekly" {
+		t.Errorf("CreateGroupSnapshot snapMirrorLabel = %v", got)
+	}
+	if d.Id() != "group-12" || d.Get("created_group_snapshot_id") != 12 || d.Get("volume_ids").(*schema.Set).Len() != 2 {
+		t.Errorf("unexpected state after create: id %q, %v", d.Id(), d.State().Attributes)
+	}
+	if d.Get("snapmirror_label") != "weekly" {
+		t.Errorf("snapmirror_label = %v, want the label of the members", d.Get("snapmirror_label"))
+	}
+}
+
+func TestSnapshotImport(t *testing.T) {
+	r := resourceElementswSnapshot()
+	for id, want := range map[string]string{"10": "snap-10", "snap-10": "snap-10", "group-12": "group-12"} {
+		d := r.TestResourceData()
+		d.SetId(id)
+		res, err := r.Importer.State(d, nil)
+		if err != nil {
+			t.Fatalf("import %s: %s", id, err)
+		}
+		if res[0].Id() != want {
+			t.Errorf("import %s: ID %s, want %s", id, res[0].Id(), want)
+		}
+	}
+	d := r.TestResourceData()
+	d.SetId("vol-10")
+	if _, err := r.Importer.State(d, nil); err == nil {
+		t.Error("expected an error for an invalid ID")
+	}
+}
+
+func TestSnapshotExpiration(t *testing.T) {
+	got, err := snapshotExpiration("2026-10-01T12:00:00Z", "720:30:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 10, 31, 12, 30, 0, 0, time.UTC).Format(time.RFC3339); got != want {
+		t.Errorf("snapshotExpiration = %s, want %s", got, want)
+	}
+	for _, bad := range []string{"24:00", "1:60:00", "a:00:00", "1:0:00"} {
+		if _, err := parseRetention(bad); err == nil {
+			t.Errorf("parseRetention(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package solidfire
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceElementSwVirtualNetwork manages a tagged (VLAN) virtual network for iSCSI tenant isolation
+func resourceElementSwVirtualNetwork() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceElementSwVirtualNetworkCreate,
+		Read:   resourceElementSwVirtualNetworkRead,
+		Update: resourceElementSwVirtualNetworkUpdate,
+		Delete: resourceElementSwVirtualNetworkDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"tag": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(int)
+					if value < 1 || value > 4095 {
+						errors = append(errors, fmt.Errorf("%q must be a VLAN tag between 1 and 4095", k))
+					}
+					return
+				},
+				Description: "VLAN tag of the virtual network.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the virtual network.",
+			},
+			"address_blocks": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Ranges of IP addresses assigned to the storage nodes on this virtual network. Each node needs one address.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "First IP address of the block.",
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Number of IP addresses in the block.",
+						},
+						"available": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Binary string showing which addresses in the block are free (1) or used (0).",
+						},
+					},
+				},
+			},
+			"netmask": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Netmask of the virtual network.",
+			},
+			"svip": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Storage virtual IP address for the virtual network.",
+			},
+			"gateway": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Gateway of the virtual network. Only used when namespace is enabled.",
+			},
+			"namespace": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true, // cannot be changed with ModifyVirtualNetwork
+				Description: "Create the virtual network in its own network namespace (routed VRF).",
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Attributes of the virtual network. Values that are not strings are read as JSON, and JSON objects and arrays are sent decoded.",
+			},
+			"virtual_network_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the virtual network. Use it in the virtual_network_ids of solidfire_initiator.",
+			},
+		},
+	}
+}
+
+func expandVirtualNetworkAddressBlocks(v interface{}) []map[string]interface{} {
+	var blocks []map[string]interface{}
+	for _, raw := range v.([]interface{}) {
+		m := raw.(map[string]interface{})
+		blocks = append(blocks, map[string]interface{}{
+			"start": m["start"].(string),
+			"size":  m["size"].(int),
+		})
+	}
+	return blocks
+}
+
+func resourceElementSwVirtualNetworkCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+
+	params := map[string]interface{}{
+		"virtualNetworkTag": d.Get("tag").(int),
+		"name":              d.Get("name").(string),
+		"addressBlocks":     expandVirtualNetworkAddressBlocks(d.Get("address_blocks")),
+		"netmask":           d.Get("netmask").(string),
+		"svip":              d.Get("svip").(string),
+		"namespace":         d.Get("namespace").(bool),
+	}
+	if v, ok := d.GetOk("gateway"); ok {
+		params["gateway"] = v.(string)
+	}
+	if v, ok := d.GetOk("attributes"); ok {
+		params["attributes"] = expandAttributes(v.(map[string]interface{}))
+	}
+
+	id, err := client.AddVirtualNetwork(params)
+	if err != nil {
+		return fmt.Errorf("AddVirtualNetwork failed: %w", err)
+	}
+	d.SetId(strconv.FormatInt(id, 10))
+
+	return resourceElementSwVirtualNetworkRead(d, meta)
+}
+
+func resourceElementSwVirtualNetworkRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid virtual network ID %q: %w", d.Id(), err)
+	}
+
+	vn, err := client.GetVirtualNetwork(id)
+	if err != nil {
+		return err
+	}
+	if vn == nil {
+		d.SetId("")
+		return nil
+	}
+
+	blocks := make([]interface{}, 0, len(vn.AddressBlocks))
+	for _, b := range vn.AddressBlocks {
+		blocks = append(blocks, map[string]interface{}{
+			"start":     b.Start,
+			"size":      int(b.Size),
+			"available": b.Available,
+		})
+	}
+
+	d.Set("virtual_network_id", int(vn.VirtualNetworkID))
+	d.Set("tag", int(vn.VirtualNetworkTag))
+	d.Set("name", vn.Name)
+	d.Set("netmask", vn.Netmask)
+	d.Set("svip", vn.Svip)
+	d.Set("gateway", vn.Gateway)
+	d.Set("namespace", vn.Namespace)
+	if err := d.Set("attributes", flattenAttributes(vn.Attributes)); err != nil {
+		return err
+	}
+	if err := d.Set("address_blocks", blocks); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceElementSwVirtualNetworkUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	params := map[string]interface{}{
+		"virtualNetworkID": id,
+	}
+	if d.HasChange("tag") {
+		params["virtualNetworkTag"] = d.Get("tag").(int)
+	}
+	if d.HasChange("name") {
+		params["name"] = d.Get("name").(string)
+	}
+	if d.HasChange("address_blocks") {
+		params["addressBlocks"] = expandVirtualNetworkAddressBlocks(d.Get("address_blocks"))
+	}
+	if d.HasChange("netmask") {
+		params["netmask"] = d.Get("netmask").(string)
+	}
+	if d.HasChange("svip") {
+		params["svip"] = d.Get("svip").(string)
+	}
+	if d.HasChange("gateway") {
+		params["gateway"] = d.Get("gateway").(string)
+	}
+	if d.HasChange("attributes") {
+		params["attributes"] = expandAttributes(d.Get("attributes").(map[string]interface{}))
+	}
+
+	if err := client.ModifyVirtualNetwork(params); err != nil {
+		return fmt.Errorf("ModifyVirtualNetwork failed: %w", err)
+	}
+
+	return resourceElementSwVirtualNetworkRead(d, meta)
+}
+
+func resourceElementSwVirtualNetworkDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	if err := client.RemoveVirtualNetwork(id); err != nil {
+		return fmt.Errorf("RemoveVirtualNetwork failed: %w", err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,109 @@
+package solidfire
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func TestAccElementswVirtualNetwork_basic(t *testing.T) {
+	svip := os.Getenv("SOLIDFIRE_VLAN_SVIP")
+	start := os.Getenv("SOLIDFIRE_VLAN_ADDRESS_START")
+	if svip == "" || start == "" {
+		t.Skip("SOLIDFIRE_VLAN_SVIP and SOLIDFIRE_VLAN_ADDRESS_START must be set for virtual network tests")
+	}
+
+	resourceName := "solidfire_virtual_network.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVirtualNetworkConfig("tf-acc-vlan", svip, start),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "virtual_network_id"),
+					resource.TestCheckResourceAttr(resourceName, "tag", "3999"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-vlan"),
+					resource.TestCheckResourceAttr(resourceName, "address_blocks.#", "1"),
+					resource.TestCheckResourceAttr("solidfire_initiator.test", "virtual_network_ids.#", "1"),
+				),
+			},
+			{
+				Config: testAccVirtualNetworkConfig("tf-acc-vlan-renamed", svip, start),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-vlan-renamed"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccVirtualNetworkConfig(name, svip, start string) string {
+	return fmt.Sprintf(`
+resource "solidfire_virtual_network" "test" {
+  tag     = 3999
+  name    = "%s"
+  netmask = "255.255.255.0"
+  svip    = "%s"
+
+  address_blocks {
+    start = "%s"
+    size  = 4
+  }
+}
+
+resource "solidfire_initiator" "test" {
+  name                = "iqn.1998-01.com.vmware:tf-acc-vlan"
+  virtual_network_ids = [solidfire_virtual_network.test.virtual_network_id]
+}
+`, name, svip, start)
+}
+
+func TestVirtualNetworkAttributesRoundTrip(t *testing.T) {
+	api := newFakeAPI()
+	var sent map[string]interface{}
+	api.handle("AddVirtualNetwork", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		sent = p["attributes"].(map[string]interface{})
+		return map[string]interface{}{"virtualNetworkID": 3}, nil
+	})
+	api.handle("ListVirtualNetworks", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{"virtualNetworks": []interface{}{map[string]interface{}{
+			"virtualNetworkID": 3, "virtualNetworkTag": 100, "name": "tenant", "svip": "10.1.0.10", "netmask": "255.255.255.0",
+			"attributes": map[string]interface{}{"owner": "ops", "rack": 12, "labels": map[string]interface{}{"env": "prod"}},
+		}}}, nil
+	})
+	client := newFakeAPIClient(t, api)
+	r := resourceElementSwVirtualNetwork()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tag":        100,
+		"name":       "tenant",
+		"svip":       "10.1.0.10",
+		"netmask":    "255.255.255.0",
+		"attributes": map[string]interface{}{"owner": "ops", "labels": `{"env":"prod"}`},
+		"address_blocks": []interface{}{map[string]interface{}{
+			"start": "10.1.0.20", "size": 10,
+		}},
+	})
+	if err := r.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"owner": "ops", "labels": map[string]interface{}{"env": "prod"}}
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("AddVirtualNetwork attributes = %v, want %v", sent, want)
+	}
+	got := d.Get("attributes").(map[string]interface{})
+	if got["owner"] != "ops" || got["rack"] != "12" || got["labels"] != `{"env":"prod"}` {
+		t.Errorf("attributes not read back: %v", got)
+	}
+}
@@ -1,11 +1,10 @@
 package solidfire
 
 import (
-	"context"
 	"fmt"
-	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleoutsean/solidfire-go/sdk"
 )
@@ -20,6 +19,10 @@ func resourceElementSwVolume() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: requireAPIFeatures(map[string]apiFeature{
+			"qos_policy_id":                 featureQoSPolicies,
+			"enable_snapmirror_replication": featureSnapMirror,
+		}),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -108,6 +111,11 @@ func resourceElementSwVolume() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"enable_snapmirror_replication": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the volume can be replicated with SnapMirror to ONTAP. Requires Element API 10.1 or later.",
+			},
 		},
 	}
 }
@@ -134,6 +142,8 @@ func resourceElementSwVolumeCreate(d *schema.ResourceData, meta interface{}) err
 		AccountID:  accountID,
 		TotalSize:  int64(d.Get("total_size").(int)),
 		Enable512e: d.Get("enable512e").(bool),
+
+		EnableSnapMirrorReplication: d.Get("enable_snapmirror_replication").(bool),
 	}
 
 	if v, ok := d.GetOk("access"); ok {
@@ -156,7 +166,7 @@ func resourceElementSwVolumeCreate(d *schema.ResourceData, meta interface{}) err
 	}
 
 	client.initOnce.Do(client.init)
-	resp, sdkErr := client.sdkClient.CreateVolume(context.TODO(), &req)
+	resp, sdkErr := callSDK(client, "CreateVolume", (*sdk.SFClient).CreateVolume, &req)
 	if sdkErr != nil {
 		return fmt.Errorf("CreateVolume failed: %s", sdkErr.Detail)
 	}
@@ -180,6 +190,7 @@ func resourceElementSwVolumeRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("enable512e", vol.Enable512e)
 	d.Set("iqn", vol.Iqn)
 	d.Set("access", vol.Access)
+	d.Set("enable_snapmirror_replication", vol.EnableSnapMirrorReplication)
 	if vol.QosPolicyID != 0 {
 		d.Set("qos_policy_id", int(vol.QosPolicyID))
 	} else {
@@ -216,11 +227,22 @@ func resourceElementSwVolumeUpdate(d *schema.ResourceData, meta interface{}) err
 	}
 
 	client.initOnce.Do(client.init)
-	_, sdkErr := client.sdkClient.ModifyVolume(context.TODO(), &req)
+	_, sdkErr := callSDK(client, "ModifyVolume", (*sdk.SFClient).ModifyVolume, &req)
 	if sdkErr != nil {
 		return fmt.Errorf("ModifyVolume failed: %s", sdkErr.Detail)
 	}
 
+	// ModifyVolumeRequest omits false booleans, so the flag is sent as a raw parameter
+	if d.HasChange("enable_snapmirror_replication") {
+		_, err := client.CallAPIMethod("ModifyVolume", map[string]interface{}{
+			"volumeID":                    id,
+			"enableSnapMirrorReplication": d.Get("enable_snapmirror_replication").(bool),
+		})
+		if err != nil {
+			return fmt.Errorf("ModifyVolume failed: %w", err)
+		}
+	}
+
 	return resourceElementSwVolumeRead(d, meta)
 }
 
@@ -229,14 +251,17 @@ func resourceElementSwVolumeDelete(d *schema.ResourceData, meta interface{}) err
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
 	client.initOnce.Do(client.init)
-	_, sdkErr := client.sdkClient.DeleteVolume(context.TODO(), &sdk.DeleteVolumeRequest{VolumeID: id})
+	_, sdkErr := callSDK(client, "DeleteVolume", (*sdk.SFClient).DeleteVolume, &sdk.DeleteVolumeRequest{VolumeID: id})
 	if sdkErr != nil {
 		return fmt.Errorf("DeleteVolume failed: %s", sdkErr.Detail)
 	}
 
-	_, sdkErr = client.sdkClient.PurgeDeletedVolume(context.TODO(), &sdk.PurgeDeletedVolumeRequest{VolumeID: id})
+	_, sdkErr = callSDK(client, "PurgeDeletedVolume", (*sdk.SFClient).PurgeDeletedVolume, &sdk.PurgeDeletedVolumeRequest{VolumeID: id})
 	if sdkErr != nil {
-		log.Printf("[WARN] PurgeDeletedVolume failed for %d: %s", id, sdkErr.Detail)
+		tflog.SubsystemWarn(client.logContext(), logResource, "PurgeDeletedVolume failed, the volume stays deleted until it is purged", map[string]interface{}{
+			"volume_id": id,
+			"error":     sdkErr.Detail,
+		})
 	}
 
 	d.SetId("")
@@ -1,7 +1,6 @@
 package solidfire
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 
@@ -66,7 +65,7 @@ func resourceElementSwVolumeAccessGroupCreate(d *schema.ResourceData, meta inter
 	}
 
 	client.initOnce.Do(client.init)
-	res, sdkErr := client.sdkClient.CreateVolumeAccessGroup(context.TODO(), &req)
+	res, sdkErr := callSDK(client, "CreateVolumeAccessGroup", (*sdk.SFClient).CreateVolumeAccessGroup, &req)
 	if sdkErr != nil {
 		if sdkErr != nil {
 			return sdkErr
@@ -87,23 +86,16 @@ func resourceElementSwVolumeAccessGroupRead(d *schema.ResourceData, meta interfa
 		return err
 	}
 
-	req := sdk.ListVolumeAccessGroupsRequest{
-		VolumeAccessGroups: []int64{id},
-	}
-	client.initOnce.Do(client.init)
-	res, sdkErr := client.sdkClient.ListVolumeAccessGroups(context.TODO(), &req)
-	if sdkErr != nil {
-		if sdkErr != nil {
-			return sdkErr
-		}
-		return nil
+	vags, err := client.listVolumeAccessGroupsByID(id)
+	if err != nil {
+		return err
 	}
 
-	if len(res.VolumeAccessGroups) != 1 {
+	if len(vags) != 1 {
 		return fmt.Errorf("expected one volume access group")
 	}
 
-	vag := res.VolumeAccessGroups[0]
+	vag := vags[0]
 	d.Set("name", vag.Name)
 	d.Set("initiators", vag.Initiators)
 	d.Set("volumes", vag.Volumes)
@@ -132,7 +124,7 @@ func resourceElementSwVolumeAccessGroupUpdate(d *schema.ResourceData, meta inter
 	}
 
 	client.initOnce.Do(client.init)
-	_, sdkErr := client.sdkClient.ModifyVolumeAccessGroup(context.TODO(), &req)
+	_, sdkErr := callSDK(client, "ModifyVolumeAccessGroup", (*sdk.SFClient).ModifyVolumeAccessGroup, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
@@ -148,7 +140,7 @@ func resourceElementSwVolumeAccessGroupDelete(d *schema.ResourceData, meta inter
 		VolumeAccessGroupID: id,
 	}
 	client.initOnce.Do(client.init)
-	_, sdkErr := client.sdkClient.DeleteVolumeAccessGroup(context.TODO(), &req)
+	_, sdkErr := callSDK(client, "DeleteVolumeAccessGroup", (*sdk.SFClient).DeleteVolumeAccessGroup, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
@@ -163,17 +155,13 @@ func resourceElementSwVolumeAccessGroupExists(d *schema.ResourceData, meta inter
 		return false, nil
 	}
 
-	req := sdk.ListVolumeAccessGroupsRequest{
-		VolumeAccessGroups: []int64{id},
-	}
-	client.initOnce.Do(client.init)
-	res, sdkErr := client.sdkClient.ListVolumeAccessGroups(context.TODO(), &req)
-	if sdkErr != nil {
-		if sdkErr.Detail == "500:xUnknown" {
+	vags, err := client.listVolumeAccessGroupsByID(id)
+	if err != nil {
+		if sdkErr, ok := err.(*sdk.SdkError); ok && sdkErr.Detail == "500:xUnknown" {
 			return false, nil
 		}
-		return false, sdkErr
+		return false, err
 	}
 
-	return len(res.VolumeAccessGroups) == 1, nil
+	return len(vags) == 1, nil
 }
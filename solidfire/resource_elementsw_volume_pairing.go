@@ -1,21 +1,37 @@
 package solidfire
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
 // resourceElementSwVolumePairing manages SolidFire volume pairing (replication)
 func resourceElementSwVolumePairing() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceElementSwVolumePairingCreate,
 		Read:   resourceElementSwVolumePairingRead,
 		Update: resourceElementSwVolumePairingUpdate,
 		Delete: resourceElementSwVolumePairingDelete,
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if _, ok := d.GetOk("target_volume"); ok {
+				_, inline := d.GetOk("target_cluster")
+				_, profile := d.GetOk("target_cluster_profile")
+				if !inline && !profile {
+					return fmt.Errorf("target_volume needs target_cluster or target_cluster_profile")
+				}
+			}
+			client, ok := meta.(*Client)
+			if !ok || d.Get("mode").(string) != "SnapMirror" {
+				return nil
+			}
+			return client.requireAPIFeature(featureSnapMirror, `mode = "SnapMirror"`)
+		},
 		Schema: map[string]*schema.Schema{
 			"volume_id": {
 				Type:        schema.TypeInt,
@@ -50,12 +66,136 @@ func resourceElementSwVolumePairing() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "Whether to pause the volume pairing.",
+				Description: "Whether to pause the volume pairing on this cluster. A pause on the remote cluster shows as `PausedManualRemote` in `replication_state`.",
 			},
 			// Automated pairing support
-			"target_cluster": clusterConnectionSchema("Target cluster for pairing (API endpoint, username, password)"),
+			"target_cluster":         clusterConnectionSchema("Target cluster for pairing (API endpoint, username, password)"),
+			"target_cluster_profile": clusterProfileNameSchema("Name of the provider's cluster profile to use as the target cluster, instead of target_cluster."),
+			"target_volume": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Create the replication target volume on the target cluster instead of looking for a volume with the source volume's name. Needs target_cluster or target_cluster_profile.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The account on the target cluster that owns the target volume.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The name of the target volume. Defaults to the source volume's name.",
+						},
+						"qos_policy_id": {
+							Type:          schema.TypeInt,
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"target_volume.0.min_iops", "target_volume.0.max_iops", "target_volume.0.burst_iops"},
+							Description:   "The QoS policy on the target cluster to assign to the target volume.",
+						},
+						"min_iops": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"max_iops": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"burst_iops": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"delete_on_destroy": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Delete and purge the target volume when the pairing is destroyed. Can be changed without replacing the pairing.",
+						},
+					},
+				},
+			},
+			"target_volume_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the target volume the provider created.",
+			},
+			// Replication status
+			"cluster_pair_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the cluster pair the volume is replicated over.",
+			},
+			"remote_cluster_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the remote cluster.",
+			},
+			"remote_cluster_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the remote cluster.",
+			},
+			"remote_volume_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the paired volume on the remote cluster.",
+			},
+			"remote_volume_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the paired volume on the remote cluster.",
+			},
+			"volume_pair_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the volume pair.",
+			},
+			"replication_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The replication state, e.g. Active, Idle, PausedManual, PausedManualRemote (paused on the remote cluster), PausedDisconnected or PausedMisconfigured.",
+			},
+			"replication_state_details": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Details about the replication state.",
+			},
+			"paused_automatically": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster paused replication on its own, e.g. because the clusters are disconnected.",
+			},
+			"snapshot_replication_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of snapshot replication.",
+			},
+			"snapshot_replication_state_details": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Details about the state of snapshot replication.",
+			},
+			"replication_lag_seconds": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "How far the remote volume lags behind, for Async and SnapMirror pairs.",
+			},
+			"last_sync_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the remote volume was last in sync (RFC3339), for Async and SnapMirror pairs.",
+			},
 		},
 	}
+	clusterAlternatives(r.Schema, "target_cluster", "target_cluster_profile", false)
+	return r
 }
 
 func resourceElementSwVolumePairingCreate(d *schema.ResourceData, meta interface{}) error {
@@ -72,103 +212,113 @@ func resourceElementSwVolumePairingCreate(d *schema.ResourceData, meta interface
 	d.Set("pairing_key", resp.VolumePairingKey)
 	d.SetId(fmt.Sprintf("%d", volumeID))
 
-	// 2. If target_cluster is provided, complete pairing on target
-	if targetList, ok := d.GetOk("target_cluster"); ok {
-		targetConn := expandClusterConnection(targetList)
-		if targetConn != nil {
-			// Create target client
-			targetSF, err := createSFClientFromConn(targetConn)
-			if err != nil {
-				return fmt.Errorf("failed to create target cluster client: %w", err)
-			}
-			targetClient := &Client{sdkClient: targetSF}
-			// Important: Mark as initialized so targetClient.init() doesn't overwrite our SDK client
-			targetClient.initOnce.Do(func() {})
+	// 2. If the target cluster is provided, complete pairing on target
+	targetClient, err := remoteClusterClient(d, meta, "target_cluster", "target_cluster_profile")
+	if err != nil {
+		return fmt.Errorf("failed to create target cluster client: %w", err)
+	}
+	if targetClient != nil {
+		// We need the target volume ID: either create it, or find a volume with the
+		// source volume's name on the target.
 
-			// We need the target volume ID.
-			// Strategy: Get source volume name, find volume with same name on target.
+		// Get source volume details
+		vol, err := client.GetVolume(volumeID)
+		if err != nil {
+			return fmt.Errorf("failed to get source volume details: %w", err)
+		}
+		sourceVolName := vol.Name
 
-			// Get source volume details
-			vol, err := client.GetVolume(volumeID)
+		targetVolumeID := int64(0)
+		if spec := expandTargetVolume(d.Get("target_volume")); spec != nil {
+			targetVolumeID, err = createReplicationTargetVolume(targetClient, vol, spec)
 			if err != nil {
-				return fmt.Errorf("failed to get source volume details: %w", err)
+				return fmt.Errorf("failed to create target volume: %w", err)
 			}
-			sourceVolName := vol.Name
-
-			// Find volume on target with retry
-			targetVolumeID := int64(0)
-			for attempt := 0; attempt < 10; attempt++ {
-				startID := int64(0)
-				for {
-					req := sdk.ListActiveVolumesRequest{
-						StartVolumeID: startID,
-						Limit:         1000,
-					}
-					volumes, err := targetClient.ListActiveVolumes(&req)
-					if err != nil {
-						return fmt.Errorf("failed to list volumes on target: %w", err)
-					}
+			// Recorded right away so that a failed pairing still deletes it on destroy
+			d.Set("target_volume_id", int(targetVolumeID))
+			tflog.SubsystemInfo(client.logContext(), logReplication, "Created replication target volume", map[string]interface{}{
+				"target_volume_id": targetVolumeID,
+				"account_id":       spec.AccountID,
+			})
+		}
 
-					if len(volumes) == 0 {
-						break
-					}
+		// Find volume on target with retry
+		for attempt := 0; targetVolumeID == 0 && attempt < 10; attempt++ {
+			startID := int64(0)
+			for {
+				req := sdk.ListActiveVolumesRequest{
+					StartVolumeID: startID,
+					Limit:         1000,
+				}
+				volumes, err := targetClient.ListActiveVolumes(&req)
+				if err != nil {
+					return fmt.Errorf("failed to list volumes on target: %w", err)
+				}
 
-					for _, v := range volumes {
-						if v.Name == sourceVolName {
-							targetVolumeID = v.VolumeID
-							break
-						}
-						if v.VolumeID > startID {
-							startID = v.VolumeID
-						}
-					}
-					if targetVolumeID != 0 || len(volumes) < 1000 {
+				if len(volumes) == 0 {
+					break
+				}
+
+				for _, v := range volumes {
+					if v.Name == sourceVolName {
+						targetVolumeID = v.VolumeID
 						break
 					}
-					startID++
+					if v.VolumeID > startID {
+						startID = v.VolumeID
+					}
 				}
-				if targetVolumeID != 0 {
+				if targetVolumeID != 0 || len(volumes) < 1000 {
 					break
 				}
-				time.Sleep(2 * time.Second)
+				startID++
 			}
-
-			if targetVolumeID == 0 {
-				return fmt.Errorf("target volume with name '%s' not found on target cluster after retries", sourceVolName)
+			if targetVolumeID != 0 {
+				break
 			}
+			time.Sleep(2 * time.Second)
+		}
 
-			// Ensure target volume is set to replicationTarget mode before pairing
-			ourlog.Infof("Setting target volume %d to 'replicationTarget' mode", targetVolumeID)
-			modifyReq := &sdk.ModifyVolumeRequest{
-				VolumeID: targetVolumeID,
-				Access:   "replicationTarget",
-			}
-			err = targetClient.ModifyVolume(modifyReq)
-			if err != nil {
-				return fmt.Errorf("failed to set target volume to replicationTarget: %w", err)
-			}
+		if targetVolumeID == 0 {
+			return fmt.Errorf("target volume with name '%s' not found on target cluster after retries", sourceVolName)
+		}
 
-			// Complete pairing on target
-			// Retry as cluster pairing might not be fully established (Connected) yet
-			var lastErr error
-			var success bool
-			for i := 0; i < 20; i++ {
-				err = targetClient.CompleteVolumePairing(targetVolumeID, resp.VolumePairingKey)
-				if err == nil {
-					success = true
-					break
-				}
-				lastErr = err
-				// xMVIPNotPaired means clusters are not yet paired or transitioning
-				if !strings.Contains(err.Error(), "xMVIPNotPaired") {
-					return fmt.Errorf("failed to complete volume pairing on target: %w", err)
-				}
-				ourlog.Infof("Waiting for cluster pairing to be ready (attempt %d/20)...", i+1)
-				time.Sleep(3 * time.Second)
+		// Ensure target volume is set to replicationTarget mode before pairing
+		tflog.SubsystemInfo(client.logContext(), logReplication, "Setting target volume to replicationTarget mode", map[string]interface{}{
+			"target_volume_id": targetVolumeID,
+		})
+		modifyReq := &sdk.ModifyVolumeRequest{
+			VolumeID: targetVolumeID,
+			Access:   "replicationTarget",
+		}
+		err = targetClient.ModifyVolume(modifyReq)
+		if err != nil {
+			return fmt.Errorf("failed to set target volume to replicationTarget: %w", err)
+		}
+
+		// Complete pairing on target
+		// Retry as cluster pairing might not be fully established (Connected) yet
+		var lastErr error
+		var success bool
+		for i := 0; i < 20; i++ {
+			err = targetClient.CompleteVolumePairing(targetVolumeID, resp.VolumePairingKey)
+			if err == nil {
+				success = true
+				break
 			}
-			if !success {
-				return fmt.Errorf("failed to complete volume pairing on target after retries: %w", lastErr)
+			lastErr = err
+			// xMVIPNotPaired means clusters are not yet paired or transitioning
+			if !strings.Contains(err.Error(), "xMVIPNotPaired") {
+				return fmt.Errorf("failed to complete volume pairing on target: %w", err)
 			}
+			tflog.SubsystemInfo(client.logContext(), logReplication, "Waiting for cluster pairing to be ready", map[string]interface{}{
+				"attempt":      i + 1,
+				"max_attempts": 20,
+			})
+			time.Sleep(3 * time.Second)
+		}
+		if !success {
+			return fmt.Errorf("failed to complete volume pairing on target after retries: %w", lastErr)
 		}
 	}
 
@@ -179,19 +329,67 @@ func resourceElementSwVolumePairingRead(d *schema.ResourceData, meta interface{}
 	client := meta.(*Client)
 	volumeID := int64(d.Get("volume_id").(int))
 
-	// List active paired volumes
-	vols, err := client.ListActivePairedVolumes()
+	vol, err := client.getPairedVolume(volumeID)
 	if err != nil {
 		return fmt.Errorf("failed to list active paired volumes: %w", err)
 	}
+	if vol == nil {
+		d.SetId("") // Volume is no longer paired
+		return nil
+	}
+
+	pair := vol.VolumePairs[0]
+	if len(vol.VolumePairs) > 1 {
+		tflog.SubsystemWarn(client.logContext(), logReplication, "Volume has more than one pair, reporting the first", map[string]interface{}{
+			"volume_id":  volumeID,
+			"pair_count": len(vol.VolumePairs),
+		})
+	}
+	repl := pair.RemoteReplication
+	if repl.Mode != "" {
+		d.Set("mode", repl.Mode)
+	}
+	d.Set("paused", repl.pausedManually())
+	d.Set("paused_automatically", repl.pausedAutomatically())
+	d.Set("replication_state", repl.State)
+	d.Set("replication_state_details", repl.StateDetails)
+	d.Set("snapshot_replication_state", repl.SnapshotReplication.State)
+	d.Set("snapshot_replication_state_details", repl.SnapshotReplication.StateDetails)
+	d.Set("cluster_pair_id", int(pair.ClusterPairID))
+	d.Set("remote_volume_id", int(pair.RemoteVolumeID))
+	d.Set("remote_volume_name", pair.RemoteVolumeName)
+	d.Set("volume_pair_uuid", pair.VolumePairUUID)
+
+	clusterPairs, err := client.listClusterPairDetails()
+	if err != nil {
+		return fmt.Errorf("failed to list cluster pairs: %w", err)
+	}
+	remoteName, remoteUUID := "", ""
+	if cp := findClusterPairByID(clusterPairs, pair.ClusterPairID); cp != nil {
+		remoteName, remoteUUID = cp.ClusterName, cp.ClusterUUID
+	}
+	d.Set("remote_cluster_name", remoteName)
+	d.Set("remote_cluster_uuid", remoteUUID)
 
-	for _, vol := range vols {
-		if vol.VolumeID == volumeID {
-			return nil // Volume is still paired
+	// Sync pairs have no lag; the stats report asyncDelay only for Async and SnapMirror
+	lag, lastSync := 0.0, ""
+	stats, err := client.ListVolumeStats([]int64{volumeID})
+	if err != nil {
+		return fmt.Errorf("failed to get volume stats: %w", err)
+	}
+	if len(stats) == 1 && stats[0].AsyncDelay != nil {
+		delay, err := parseAsyncDelay(*stats[0].AsyncDelay)
+		if err != nil {
+			return err
+		}
+		lag = delay.Seconds()
+		if sampled, err := time.Parse(time.RFC3339, stats[0].Timestamp); err == nil {
+			lastSync = sampled.Add(-delay).UTC().Format(time.RFC3339)
 		}
 	}
+	d.Set("replication_lag_seconds", lag)
+	d.Set("last_sync_time", lastSync)
 
-	d.SetId("") // Volume is no longer paired
 	return nil
 }
 
@@ -199,15 +397,21 @@ func resourceElementSwVolumePairingUpdate(d *schema.ResourceData, meta interface
 	client := meta.(*Client)
 	volumeID := int64(d.Get("volume_id").(int))
 
-	// Modify volume pair
-	req := sdk.ModifyVolumePairRequest{
-		VolumeID:     volumeID,
-		PausedManual: d.Get("paused").(bool),
-		Mode:         d.Get("mode").(string),
+	// ModifyVolumePairRequest omits false booleans, which would make resuming impossible,
+	// so the changed settings are sent as raw parameters
+	params := map[string]interface{}{
+		"volumeID": volumeID,
 	}
-	err := client.ModifyVolumePair(&req)
-	if err != nil {
-		return fmt.Errorf("failed to modify volume pair: %w", err)
+	if d.HasChange("paused") {
+		params["pausedManual"] = d.Get("paused").(bool)
+	}
+	if d.HasChange("mode") {
+		params["mode"] = d.Get("mode").(string)
+	}
+	if len(params) > 1 {
+		if _, err := client.CallAPIMethod("ModifyVolumePair", params); err != nil {
+			return fmt.Errorf("failed to modify volume pair: %w", err)
+		}
 	}
 	return resourceElementSwVolumePairingRead(d, meta)
 }
@@ -217,10 +421,113 @@ func resourceElementSwVolumePairingDelete(d *schema.ResourceData, meta interface
 	volumeID := int64(d.Get("volume_id").(int))
 
 	// Remove volume pair
-	err := client.RemoveVolumePair(volumeID)
-	if err != nil {
-		return fmt.Errorf("failed to remove volume pair: %w", err)
+	if vol, err := client.getPairedVolume(volumeID); err != nil {
+		return fmt.Errorf("failed to list active paired volumes: %w", err)
+	} else if vol != nil {
+		if err := client.RemoveVolumePair(volumeID); err != nil {
+			return fmt.Errorf("failed to remove volume pair: %w", err)
+		}
 	}
+
+	targetVolumeID := int64(d.Get("target_volume_id").(int))
+	spec := expandTargetVolume(d.Get("target_volume"))
+	if targetVolumeID != 0 && spec != nil && spec.DeleteOnDestroy {
+		targetClient, err := remoteClusterClient(d, meta, "target_cluster", "target_cluster_profile")
+		if err != nil {
+			return fmt.Errorf("failed to create target cluster client: %w", err)
+		}
+		if targetClient == nil {
+			return fmt.Errorf("target_cluster or target_cluster_profile is required to delete target volume %d", targetVolumeID)
+		}
+		if err := deleteReplicationTargetVolume(targetClient, targetVolumeID); err != nil {
+			return fmt.Errorf("failed to delete target volume %d: %w", targetVolumeID, err)
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
+
+// targetVolumeSpec is the target_volume block of solidfire_volume_pairing
+type targetVolumeSpec struct {
+	AccountID       int64
+	Name            string
+	QosPolicyID     int64
+	Qos             sdk.QoS
+	DeleteOnDestroy bool
+}
+
+func expandTargetVolume(v interface{}) *targetVolumeSpec {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	m := list[0].(map[string]interface{})
+	return &targetVolumeSpec{
+		AccountID:   int64(m["account_id"].(int)),
+		Name:        m["name"].(string),
+		QosPolicyID: int64(m["qos_policy_id"].(int)),
+		Qos: sdk.QoS{
+			MinIOPS:   int64(m["min_iops"].(int)),
+			MaxIOPS:   int64(m["max_iops"].(int)),
+			BurstIOPS: int64(m["burst_iops"].(int)),
+		},
+		DeleteOnDestroy: m["delete_on_destroy"].(bool),
+	}
+}
+
+// createReplicationTargetVolume creates a volume on the target cluster matching the source
+// volume's size and sector size. Its access is switched to replicationTarget before pairing.
+func createReplicationTargetVolume(target *Client, source *sdk.Volume, spec *targetVolumeSpec) (int64, error) {
+	req := sdk.CreateVolumeRequest{
+		Name:       spec.Name,
+		AccountID:  spec.AccountID,
+		TotalSize:  source.TotalSize,
+		Enable512e: source.Enable512e,
+	}
+	if req.Name == "" {
+		req.Name = source.Name
+	}
+	if spec.QosPolicyID != 0 {
+		req.QosPolicyID = spec.QosPolicyID
+	} else if spec.Qos != (sdk.QoS{}) {
+		qos := spec.Qos
+		req.Qos = &qos
+	}
+
+	target.initOnce.Do(target.init)
+	resp, sdkErr := callSDK(target, "CreateVolume", (*sdk.SFClient).CreateVolume, &req)
+	if sdkErr != nil {
+		return 0, sdkErr
+	}
+	return resp.VolumeID, nil
+}
+
+// deleteReplicationTargetVolume removes the target side of the pair, then deletes and purges
+// the volume. A volume that is already gone is not an error.
+func deleteReplicationTargetVolume(target *Client, volumeID int64) error {
+	vols, err := target.ListVolumes([]int64{volumeID})
+	if err != nil {
+		return err
+	}
+	if len(vols) == 0 {
+		return nil
+	}
+
+	if paired, err := target.getPairedVolume(volumeID); err != nil {
+		return err
+	} else if paired != nil {
+		if err := target.RemoveVolumePair(volumeID); err != nil {
+			return err
+		}
+	}
+
+	target.initOnce.Do(target.init)
+	if _, sdkErr := callSDK(target, "DeleteVolume", (*sdk.SFClient).DeleteVolume, &sdk.DeleteVolumeRequest{VolumeID: volumeID}); sdkErr != nil {
+		return sdkErr
+	}
+	if _, sdkErr := callSDK(target, "PurgeDeletedVolume", (*sdk.SFClient).PurgeDeletedVolume, &sdk.PurgeDeletedVolumeRequest{VolumeID: volumeID}); sdkErr != nil {
+		return sdkErr
+	}
+	return nil
+}
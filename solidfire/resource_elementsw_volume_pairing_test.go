@@ -0,0 +1,221 @@
+package solidfire
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scaleoutsean/solidfire-go/sdk"
+)
+
+func TestVolumePairingTargetVolumeForceNew(t *testing.T) {
+	r := resourceElementSwVolumePairing()
+	state := &terraform.InstanceState{
+		ID: "5",
+		Attributes: map[string]string{
+			"id": "5", "volume_id": "5", "mode": "Async", "paused": "false", "target_cluster_profile": "dr",
+			"target_volume.#": "1", "target_volume.0.account_id": "3", "target_volume.0.name": "",
+			"target_volume.0.qos_policy_id": "0", "target_volume.0.min_iops": "0", "target_volume.0.max_iops": "0",
+			"target_volume.0.burst_iops": "0", "target_volume.0.delete_on_destroy": "false", "target_volume_id": "9",
+		},
+	}
+	config := func(tv map[string]interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"volume_id":              5,
+			"target_cluster_profile": "dr",
+			"target_volume":          []interface{}{tv},
+		})
+	}
+
+	diff, err := r.Diff(context.Background(), state, config(map[string]interface{}{"account_id": 3, "delete_on_destroy": true}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.RequiresNew() {
+		t.Errorf("changing delete_on_destroy: got %v, want an in-place update", diff)
+	}
+
+	for name, tv := range map[string]map[string]interface{}{
+		"account_id":    {"account_id": 4},
+		"name":          {"account_id": 3, "name": "dr-vol"},
+		"qos_policy_id": {"account_id": 3, "qos_policy_id": 2},
+		"max_iops":      {"account_id": 3, "max_iops": 1000},
+	} {
+		diff, err := r.Diff(context.Background(), state, config(tv), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff == nil || !diff.RequiresNew() {
+			t.Errorf("changing %s: got %v, want a replacement", name, diff)
+		}
+	}
+}
+
+// fakeTargetVolumes serves the target cluster's volume methods from the map it returns,
+// keyed by volume ID; paired volumes are also listed as actively paired
+func fakeTargetVolumes(api *fakeAPI) map[int64]map[string]interface{} {
+	volumes := map[int64]map[string]interface{}{}
+	nextID := int64(9)
+	api.handle("CreateVolume", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		v := map[string]interface{}{"volumeID": nextID, "name": p["name"], "access": "readWrite"}
+		volumes[nextID] = v
+		nextID++
+		return map[string]interface{}{"volumeID": v["volumeID"], "volume": v}, nil
+	})
+	api.handle("ListVolumes", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		list := []interface{}{}
+		for _, id := range p["volumeIDs"].([]interface{}) {
+			if v, ok := volumes[int64(id.(float64))]; ok {
+				list = append(list, v)
+			}
+		}
+		return map[string]interface{}{"volumes": list}, nil
+	})
+	api.handle("ModifyVolume", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		volumes[int64(p["volumeID"].(float64))]["access"] = p["access"]
+		return map[string]interface{}{}, nil
+	})
+	api.handle("ListActivePairedVolumes", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		v, ok := volumes[int64(p["startVolumeID"].(float64))]
+		if !ok || v["volumePairs"] == nil {
+			return map[string]interface{}{"volumes": []interface{}{}}, nil
+		}
+		return map[string]interface{}{"volumes": []interface{}{v}}, nil
+	})
+	api.handle("RemoveVolumePair", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		delete(volumes[int64(p["volumeID"].(float64))], "volumePairs")
+		return map[string]interface{}{}, nil
+	})
+	api.handle("DeleteVolume", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		volumes[int64(p["volumeID"].(float64))]["status"] = "deleted"
+		return map[string]interface{}{}, nil
+	})
+	api.handle("PurgeDeletedVolume", func(p map[string]interface{}) (interface{}, *sdk.SdkError) {
+		delete(volumes, int64(p["volumeID"].(float64)))
+		return map[string]interface{}{}, nil
+	})
+	return volumes
+}
+
+func TestCreateReplicationTargetVolume(t *testing.T) {
+	source := &sdk.Volume{VolumeID: 5, Name: "db", TotalSize: 10737418240, Enable512e: true}
+	for _, tc := range []struct {
+		name string
+		spec targetVolumeSpec
+		want map[string]interface{}
+	}{
+		{
+			name: "QoS policy",
+			spec: targetVolumeSpec{AccountID: 3, QosPolicyID: 2},
+			want: map[string]interface{}{
+				"name": "db", "accountID": float64(3), "totalSize": float64(10737418240), "enable512e": true,
+				"qosPolicyID": float64(2),
+			},
+		},
+		{
+			name: "explicit IOPS",
+			spec: targetVolumeSpec{AccountID: 3, Name: "db-dr", Qos: sdk.QoS{MinIOPS: 100, MaxIOPS: 1000, BurstIOPS: 2000}},
+			want: map[string]interface{}{
+				"name": "db-dr", "accountID": float64(3), "totalSize": float64(10737418240), "enable512e": true,
+				"qos": map[string]interface{}{"minIOPS": float64(100), "maxIOPS": float64(1000), "burstIOPS": float64(2000)},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newFakeAPI()
+			fakeTargetVolumes(api)
+			id, err := createReplicationTargetVolume(newFakeAPIClient(t, api), source, &tc.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != 9 {
+				t.Errorf("got volume %d, want 9", id)
+			}
+			if got := api.lastParams("CreateVolume"); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("CreateVolume params = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeleteReplicationTargetVolume(t *testing.T) {
+	api := newFakeAPI()
+	volumes := fakeTargetVolumes(api)
+	target := newFakeAPIClient(t, api)
+	volumes[9] = map[string]interface{}{"volumeID": 9, "name": "db", "volumePairs": []interface{}{
+		map[string]interface{}{"clusterPairID": 1, "remoteVolumeID": 5},
+	}}
+
+	if err := deleteReplicationTargetVolume(target, 9); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"ListVolumes", "ListActivePairedVolumes", "RemoveVolumePair", "DeleteVolume", "PurgeDeletedVolume"}
+	if got := api.called(); !reflect.DeepEqual(got, want) {
+		t.Errorf("called %v, want %v", got, want)
+	}
+	if _, ok := volumes[9]; ok {
+		t.Error("target volume was not purged")
+	}
+
+	// a second destroy finds the volume gone and does nothing
+	api = newFakeAPI()
+	fakeTargetVolumes(api)
+	if err := deleteReplicationTargetVolume(newFakeAPIClient(t, api), 9); err != nil {
+		t.Fatalf("deleting a volume that is gone: %v", err)
+	}
+	if got := api.called(); !reflect.DeepEqual(got, []string{"ListVolumes"}) {
+		t.Errorf("called %v for a volume that is gone, want only ListVolumes", got)
+	}
+}
+
+func TestVolumePairingRecordsTargetVolumeBeforePairing(t *testing.T) {
+	targetAPI := newFakeAPI()
+	volumes := fakeTargetVolumes(targetAPI)
+	targetAPI.handle("CompleteVolumePairing", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return nil, &sdk.SdkError{Code: "xInvalidPairingKey", Detail: "the pairing key is not valid"}
+	})
+	profile := &clusterProfile{name: "dr", client: newFakeAPIClient(t, targetAPI)}
+	profile.once.Do(func() {})
+
+	sourceAPI := newFakeAPI()
+	sourceAPI.handle("StartVolumePairing", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{"volumePairingKey": "key"}, nil
+	})
+	sourceAPI.handle("ListVolumes", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{"volumes": []interface{}{
+			map[string]interface{}{"volumeID": 5, "name": "db", "totalSize": 10737418240, "enable512e": true},
+		}}, nil
+	})
+	sourceAPI.handle("ListActivePairedVolumes", func(map[string]interface{}) (interface{}, *sdk.SdkError) {
+		return map[string]interface{}{"volumes": []interface{}{}}, nil
+	})
+	client := newFakeAPIClient(t, sourceAPI)
+	client.profiles = map[string]*clusterProfile{"dr": profile}
+
+	r := resourceElementSwVolumePairing()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"volume_id":              5,
+		"target_cluster_profile": "dr",
+		"target_volume": []interface{}{map[string]interface{}{
+			"account_id": 3, "delete_on_destroy": true,
+		}},
+	})
+	if err := r.Create(d, client); err == nil {
+		t.Fatal("expected the failed pairing to be reported")
+	}
+	if got := d.Get("target_volume_id"); got != 9 {
+		t.Fatalf("target_volume_id = %v, want 9 recorded before pairing", got)
+	}
+	if volumes[9]["access"] != "replicationTarget" {
+		t.Errorf("target volume access = %v, want replicationTarget", volumes[9]["access"])
+	}
+
+	if err := r.Delete(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := volumes[9]; ok {
+		t.Error("destroy after the failed pairing left the target volume behind")
+	}
+}
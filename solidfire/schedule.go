@@ -1,47 +1,111 @@
 package solidfire
 
 import (
-	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
-func (c *Client) CreateSchedule(req *sdk.CreateScheduleRequest) (int64, error) {
-	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.CreateSchedule(context.TODO(), req)
-	if sdkErr != nil {
-		return 0, sdkErr
+// scheduleInfo is what a snapshot schedule creates. Element reports volume IDs as numbers or
+// strings depending on the release, so they are decoded as json.Number.
+type scheduleInfo struct {
+	VolumeID                json.Number   `json:"volumeID"`
+	Volumes                 []json.Number `json:"volumes"`
+	Name                    string        `json:"name"`
+	Retention               string        `json:"retention"`
+	SnapMirrorLabel         string        `json:"snapMirrorLabel"`
+	EnableRemoteReplication bool          `json:"enableRemoteReplication"`
+	EnsureSerialCreation    bool          `json:"ensureSerialCreation"`
+}
+
+// volumeIDs returns the volumes of a single volume or group snapshot schedule
+func (i scheduleInfo) volumeIDs() []int64 {
+	ids := i.Volumes
+	if len(ids) == 0 && i.VolumeID != "" {
+		ids = []json.Number{i.VolumeID}
+	}
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if n, err := id.Int64(); err == nil {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// scheduleWeekday is a day a "Days Of Week" schedule runs on, 0 being Sunday
+type scheduleWeekday struct {
+	Day    int64 `json:"day"`
+	Offset int64 `json:"offset"`
+}
+
+// schedule is a schedule as returned by ListSchedules. The SDK's Schedule only knows the
+// volumeID and retention of scheduleInfo, so schedules are read with raw calls.
+type schedule struct {
+	ScheduleID         int64                  `json:"scheduleID"`
+	ScheduleName       string                 `json:"scheduleName"`
+	ScheduleType       string                 `json:"scheduleType"`
+	Attributes         map[string]interface{} `json:"attributes"`
+	Hours              int64                  `json:"hours"`
+	Minutes            int64                  `json:"minutes"`
+	Monthdays          []int64                `json:"monthdays"`
+	Weekdays           []scheduleWeekday      `json:"weekdays"`
+	Paused             bool                   `json:"paused"`
+	Recurring          bool                   `json:"recurring"`
+	RunNextInterval    bool                   `json:"runNextInterval"`
+	StartingDate       string                 `json:"startingDate"`
+	ToBeDeleted        bool                   `json:"toBeDeleted"`
+	ScheduleInfo       scheduleInfo           `json:"scheduleInfo"`
+	LastRunStatus      string                 `json:"lastRunStatus"`
+	LastRunTimeStarted string                 `json:"lastRunTimeStarted"`
+}
+
+// CreateSchedule creates a schedule from raw parameters, as the SDK's CreateScheduleRequest
+// cannot carry a group or SnapMirror scheduleInfo
+func (c *Client) CreateSchedule(params map[string]interface{}) (int64, error) {
+	raw, err := c.CallAPIMethod("CreateSchedule", params)
+	if err != nil {
+		return 0, err
+	}
+	var res struct {
+		ScheduleID int64 `json:"scheduleID"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return 0, fmt.Errorf("error parsing CreateSchedule: %s", err)
 	}
 	return res.ScheduleID, nil
 }
 
-func (c *Client) GetSchedule(id int64) (*sdk.Schedule, error) {
-	// ListSchedules and filter by ID since GetSchedule return type varies
-	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListSchedules(context.TODO())
-	if sdkErr != nil {
-		return nil, sdkErr
+// GetSchedule returns the schedule with the given ID, or nil if it does not exist. It filters
+// ListSchedules since the result of GetSchedule varies between Element releases.
+func (c *Client) GetSchedule(id int64) (*schedule, error) {
+	raw, err := c.CallAPIMethod("ListSchedules", nil)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Schedules []schedule `json:"schedules"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListSchedules: %s", err)
 	}
 	for _, s := range res.Schedules {
-		if s.ScheduleID == id {
+		if s.ScheduleID == id && !s.ToBeDeleted {
 			return &s, nil
 		}
 	}
 	return nil, nil
 }
 
-func (c *Client) ModifySchedule(req *sdk.ModifyScheduleRequest) error {
-	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.ModifySchedule(context.TODO(), req)
-	if sdkErr != nil {
-		return sdkErr
-	}
-	return nil
+func (c *Client) ModifySchedule(params map[string]interface{}) error {
+	_, err := c.CallAPIMethod("ModifySchedule", params)
+	return err
 }
 
 func (c *Client) ListSchedules() ([]sdk.Schedule, error) {
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListSchedules(context.TODO())
+	res, sdkErr := callSDKNoRequest(c, "ListSchedules", (*sdk.SFClient).ListSchedules)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
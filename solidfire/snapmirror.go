@@ -0,0 +1,212 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// snapMirrorEndpoint is an ONTAP cluster the Element cluster replicates with
+type snapMirrorEndpoint struct {
+	SnapMirrorEndpointID int64    `json:"snapMirrorEndpointID"`
+	ManagementIP         string   `json:"managementIP"`
+	ClusterName          string   `json:"clusterName"`
+	Username             string   `json:"username"`
+	IPAddresses          []string `json:"ipAddresses"`
+	IsConnected          bool     `json:"isConnected"`
+}
+
+// snapMirrorVolumeInfo names one end of a SnapMirror relationship. Element volumes have type
+// "solidfire" and a volumeID, ONTAP volumes type "ontap", a vserver and a name.
+type snapMirrorVolumeInfo struct {
+	Type     string `json:"type"`
+	VolumeID int64  `json:"volumeID,omitempty"`
+	Vserver  string `json:"vserver,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+type snapMirrorRelationship struct {
+	SnapMirrorEndpointID     int64                `json:"snapMirrorEndpointID"`
+	RelationshipID           string               `json:"relationshipID"`
+	SourceVolume             snapMirrorVolumeInfo `json:"sourceVolume"`
+	DestinationVolume        snapMirrorVolumeInfo `json:"destinationVolume"`
+	RelationshipType         string               `json:"relationshipType"`
+	PolicyName               string               `json:"policyName"`
+	PolicyType               string               `json:"policyType"`
+	ScheduleName             string               `json:"scheduleName"`
+	MaxTransferRate          int64                `json:"maxTransferRate"`
+	MirrorState              string               `json:"mirrorState"`
+	RelationshipStatus       string               `json:"relationshipStatus"`
+	IsHealthy                bool                 `json:"isHealthy"`
+	UnhealthyReason          string               `json:"unhealthyReason"`
+	Lagtime                  int64                `json:"lagtime"`
+	LastTransferError        string               `json:"lastTransferError"`
+	LastTransferEndTimestamp string               `json:"lastTransferEndTimestamp"`
+}
+
+// snapMirrorVolume is an ONTAP volume seen through a SnapMirror endpoint
+type snapMirrorVolume struct {
+	SnapMirrorEndpointID int64  `json:"snapMirrorEndpointID"`
+	Name                 string `json:"name"`
+	Type                 string `json:"type"`
+	Vserver              string `json:"vserver"`
+	AggrName             string `json:"aggrName"`
+	State                string `json:"state"`
+	Size                 int64  `json:"size"`
+	AvailSize            int64  `json:"availSize"`
+}
+
+// snapMirrorAggregate is an ONTAP aggregate seen through a SnapMirror endpoint
+type snapMirrorAggregate struct {
+	SnapMirrorEndpointID int64   `json:"snapMirrorEndpointID"`
+	AggregateName        string  `json:"aggregateName"`
+	NodeName             string  `json:"nodeName"`
+	SizeAvailable        int64   `json:"sizeAvailable"`
+	SizeTotal            int64   `json:"sizeTotal"`
+	PercentUsedCapacity  float64 `json:"percentUsedCapacity"`
+	VolumeCount          int64   `json:"volumeCount"`
+}
+
+type snapMirrorEndpointResult struct {
+	SnapMirrorEndpoint snapMirrorEndpoint `json:"snapMirrorEndpoint"`
+}
+
+type snapMirrorRelationshipResult struct {
+	SnapMirrorRelationship snapMirrorRelationship `json:"snapMirrorRelationship"`
+}
+
+func (c *Client) CreateSnapMirrorEndpoint(params map[string]interface{}) (*snapMirrorEndpoint, error) {
+	raw, err := c.CallAPIMethod("CreateSnapMirrorEndpoint", params)
+	if err != nil {
+		return nil, err
+	}
+	var res snapMirrorEndpointResult
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing CreateSnapMirrorEndpoint: %s", err)
+	}
+	return &res.SnapMirrorEndpoint, nil
+}
+
+// GetSnapMirrorEndpoint returns the endpoint with the given ID, or nil if it does not exist
+func (c *Client) GetSnapMirrorEndpoint(id int64) (*snapMirrorEndpoint, error) {
+	raw, err := c.CallAPIMethod("ListSnapMirrorEndpoints", map[string]interface{}{
+		"snapMirrorEndpointIDs": []int64{id},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		SnapMirrorEndpoints []snapMirrorEndpoint `json:"snapMirrorEndpoints"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListSnapMirrorEndpoints: %s", err)
+	}
+	for _, e := range res.SnapMirrorEndpoints {
+		if e.SnapMirrorEndpointID == id {
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Client) ModifySnapMirrorEndpoint(params map[string]interface{}) error {
+	_, err := c.CallAPIMethod("ModifySnapMirrorEndpoint", params)
+	return err
+}
+
+func (c *Client) DeleteSnapMirrorEndpoint(id int64) error {
+	_, err := c.CallAPIMethod("DeleteSnapMirrorEndpoints", map[string]interface{}{
+		"snapMirrorEndpointIDs": []int64{id},
+	})
+	return err
+}
+
+func (c *Client) CreateSnapMirrorRelationship(params map[string]interface{}) (*snapMirrorRelationship, error) {
+	raw, err := c.CallAPIMethod("CreateSnapMirrorRelationship", params)
+	if err != nil {
+		return nil, err
+	}
+	var res snapMirrorRelationshipResult
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing CreateSnapMirrorRelationship: %s", err)
+	}
+	return &res.SnapMirrorRelationship, nil
+}
+
+// GetSnapMirrorRelationship returns the relationship with the given (ONTAP) ID on an endpoint,
+// or nil if it does not exist
+func (c *Client) GetSnapMirrorRelationship(endpointID int64, relationshipID string) (*snapMirrorRelationship, error) {
+	raw, err := c.CallAPIMethod("ListSnapMirrorRelationships", map[string]interface{}{
+		"snapMirrorEndpointID": endpointID,
+		"relationshipID":       relationshipID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		SnapMirrorRelationships []snapMirrorRelationship `json:"snapMirrorRelationships"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListSnapMirrorRelationships: %s", err)
+	}
+	for _, r := range res.SnapMirrorRelationships {
+		if r.RelationshipID == relationshipID {
+			return &r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Client) ModifySnapMirrorRelationship(params map[string]interface{}) error {
+	_, err := c.CallAPIMethod("ModifySnapMirrorRelationship", params)
+	return err
+}
+
+// SnapMirrorRelationshipAction runs one of the relationship operations that only take the
+// endpoint and destination volume: InitializeSnapMirrorRelationship, UpdateSnapMirrorRelationship,
+// QuiesceSnapMirrorRelationship, ResumeSnapMirrorRelationship, BreakSnapMirrorRelationship or
+// ResyncSnapMirrorRelationship
+func (c *Client) SnapMirrorRelationshipAction(method string, endpointID int64, destination snapMirrorVolumeInfo) error {
+	_, err := c.CallAPIMethod(method, map[string]interface{}{
+		"snapMirrorEndpointID": endpointID,
+		"destinationVolume":    destination,
+	})
+	return err
+}
+
+func (c *Client) DeleteSnapMirrorRelationship(endpointID int64, destination snapMirrorVolumeInfo) error {
+	_, err := c.CallAPIMethod("DeleteSnapMirrorRelationships", map[string]interface{}{
+		"snapMirrorEndpointID": endpointID,
+		"destinationVolumes":   []snapMirrorVolumeInfo{destination},
+	})
+	return err
+}
+
+func (c *Client) ListSnapMirrorVolumes(params map[string]interface{}) ([]snapMirrorVolume, error) {
+	raw, err := c.CallAPIMethod("ListSnapMirrorVolumes", params)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		SnapMirrorVolumes []snapMirrorVolume `json:"snapMirrorVolumes"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListSnapMirrorVolumes: %s", err)
+	}
+	return res.SnapMirrorVolumes, nil
+}
+
+func (c *Client) ListSnapMirrorAggregates(endpointID int64) ([]snapMirrorAggregate, error) {
+	raw, err := c.CallAPIMethod("ListSnapMirrorAggregates", map[string]interface{}{
+		"snapMirrorEndpointID": endpointID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		SnapMirrorAggregates []snapMirrorAggregate `json:"snapMirrorAggregates"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListSnapMirrorAggregates: %s", err)
+	}
+	return res.SnapMirrorAggregates, nil
+}
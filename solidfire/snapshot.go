@@ -1,18 +1,96 @@
 package solidfire
 
 import (
-	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
-func (c *Client) CreateSnapshot(req *sdk.CreateSnapshotRequest) (*sdk.CreateSnapshotResult, error) {
-	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.CreateSnapshot(context.TODO(), req)
-	if sdkErr != nil {
-		return nil, sdkErr
+// snapshotRemoteStatus is the replication state of a snapshot on the other side of a volume pair
+type snapshotRemoteStatus struct {
+	RemoteStatus   string `json:"remoteStatus"`
+	VolumePairUUID string `json:"volumePairUUID"`
+}
+
+// snapshotInfo is a snapshot as returned by ListSnapshots. The SDK's Snapshot lacks the
+// expiration and remote replication fields, so snapshots are read with raw calls.
+type snapshotInfo struct {
+	SnapshotID              int64                  `json:"snapshotID"`
+	SnapshotUUID            string                 `json:"snapshotUUID"`
+	VolumeID                int64                  `json:"volumeID"`
+	GroupID                 int64                  `json:"groupID"`
+	Name                    string                 `json:"name"`
+	Status                  string                 `json:"status"`
+	CreateTime              string                 `json:"createTime"`
+	ExpirationTime          string                 `json:"expirationTime"`
+	ExpirationReason        string                 `json:"expirationReason"`
+	EnableRemoteReplication bool                   `json:"enableRemoteReplication"`
+	SnapMirrorLabel         string                 `json:"snapMirrorLabel"`
+	RemoteStatuses          []snapshotRemoteStatus `json:"remoteStatuses"`
+	Attributes              map[string]interface{} `json:"attributes"`
+}
+
+// groupSnapshotInfo is a group snapshot as returned by ListGroupSnapshots
+type groupSnapshotInfo struct {
+	GroupSnapshotID         int64                  `json:"groupSnapshotID"`
+	GroupSnapshotUUID       string                 `json:"groupSnapshotUUID"`
+	Name                    string                 `json:"name"`
+	Status                  string                 `json:"status"`
+	CreateTime              string                 `json:"createTime"`
+	EnableRemoteReplication bool                   `json:"enableRemoteReplication"`
+	RemoteStatuses          []snapshotRemoteStatus `json:"remoteStatuses"`
+	Attributes              map[string]interface{} `json:"attributes"`
+	Members                 []snapshotInfo         `json:"members"`
+}
+
+// CreateSnapshot creates a snapshot of one volume. The SDK's CreateSnapshotRequest has no
+// expirationTime, ensureSerialCreation or attributes, so the parameters are sent as they are.
+func (c *Client) CreateSnapshot(params map[string]interface{}) (int64, error) {
+	raw, err := c.CallAPIMethod("CreateSnapshot", params)
+	if err != nil {
+		return 0, err
+	}
+	var res struct {
+		SnapshotID int64 `json:"snapshotID"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return 0, fmt.Errorf("error parsing CreateSnapshot: %s", err)
+	}
+	return res.SnapshotID, nil
+}
+
+// GetSnapshot returns the snapshot with the given ID, or nil if it does not exist
+func (c *Client) GetSnapshot(id int64) (*snapshotInfo, error) {
+	raw, err := c.CallAPIMethod("ListSnapshots", map[string]interface{}{
+		"snapshotID": id,
+	})
+	if err != nil {
+		if isDoesNotExistError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var res struct {
+		Snapshots []snapshotInfo `json:"snapshots"`
 	}
-	return res, nil
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListSnapshots: %s", err)
+	}
+	for _, s := range res.Snapshots {
+		if s.SnapshotID == id {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Client) ModifySnapshot(params map[string]interface{}) error {
+	_, err := c.CallAPIMethod("ModifySnapshot", params)
+	return err
 }
 
 func (c *Client) DeleteSnapshot(id int64) error {
@@ -20,51 +98,57 @@ func (c *Client) DeleteSnapshot(id int64) error {
 		SnapshotID: id,
 	}
 	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.DeleteSnapshot(context.TODO(), &req)
+	_, sdkErr := callSDK(c, "DeleteSnapshot", (*sdk.SFClient).DeleteSnapshot, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
 	return nil
 }
 
-func (c *Client) ListSnapshots(volumeID int64) ([]sdk.Snapshot, error) {
-	req := sdk.ListSnapshotsRequest{}
-	if volumeID > 0 {
-		req.VolumeID = volumeID
+// CreateGroupSnapshot creates a crash-consistent snapshot of several volumes. Like
+// CreateSnapshot it sends the parameters as they are, as the SDK request lacks most of them.
+func (c *Client) CreateGroupSnapshot(params map[string]interface{}) (int64, error) {
+	raw, err := c.CallAPIMethod("CreateGroupSnapshot", params)
+	if err != nil {
+		return 0, err
 	}
-	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListSnapshots(context.TODO(), &req)
-	if sdkErr != nil {
-		return nil, sdkErr
+	var res struct {
+		GroupSnapshotID int64 `json:"groupSnapshotID"`
 	}
-	return res.Snapshots, nil
-}
-
-func (c *Client) CreateGroupSnapshot(req *sdk.CreateGroupSnapshotRequest) (*sdk.CreateGroupSnapshotResult, error) {
-	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.CreateGroupSnapshot(context.TODO(), req)
-	if sdkErr != nil {
-		return nil, sdkErr
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return 0, fmt.Errorf("error parsing CreateGroupSnapshot: %s", err)
 	}
-	return res, nil
+	return res.GroupSnapshotID, nil
 }
 
-func (c *Client) ModifySnapshot(req *sdk.ModifySnapshotRequest) error {
-	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.ModifySnapshot(context.TODO(), req)
-	if sdkErr != nil {
-		return sdkErr
+// GetGroupSnapshot returns the group snapshot with the given ID, or nil if it does not exist
+func (c *Client) GetGroupSnapshot(id int64) (*groupSnapshotInfo, error) {
+	raw, err := c.CallAPIMethod("ListGroupSnapshots", map[string]interface{}{
+		"groupSnapshotID": id,
+	})
+	if err != nil {
+		if isDoesNotExistError(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	return nil
+	var res struct {
+		GroupSnapshots []groupSnapshotInfo `json:"groupSnapshots"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListGroupSnapshots: %s", err)
+	}
+	for _, gs := range res.GroupSnapshots {
+		if gs.GroupSnapshotID == id {
+			return &gs, nil
+		}
+	}
+	return nil, nil
 }
 
-func (c *Client) ModifyGroupSnapshot(req *sdk.ModifyGroupSnapshotRequest) error {
-	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.ModifyGroupSnapshot(context.TODO(), req)
-	if sdkErr != nil {
-		return sdkErr
-	}
-	return nil
+func (c *Client) ModifyGroupSnapshot(params map[string]interface{}) error {
+	_, err := c.CallAPIMethod("ModifyGroupSnapshot", params)
+	return err
 }
 
 func (c *Client) DeleteGroupSnapshot(id int64, saveMembers bool) error {
@@ -73,22 +157,46 @@ func (c *Client) DeleteGroupSnapshot(id int64, saveMembers bool) error {
 		SaveMembers:     saveMembers,
 	}
 	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.DeleteGroupSnapshot(context.TODO(), &req)
+	_, sdkErr := callSDK(c, "DeleteGroupSnapshot", (*sdk.SFClient).DeleteGroupSnapshot, &req)
 	if sdkErr != nil {
 		return sdkErr
 	}
 	return nil
 }
 
-func (c *Client) ListGroupSnapshots(volumeIDs []int64) ([]sdk.GroupSnapshot, error) {
-	req := sdk.ListGroupSnapshotsRequest{}
-	if len(volumeIDs) > 0 {
-		req.Volumes = volumeIDs
+// isDoesNotExistError reports whether a CallAPIMethod error is one of the x...DoesNotExist
+// errors Element returns for unknown object IDs
+func isDoesNotExistError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "DoesNotExist")
+}
+
+// snapshotExpiration returns the expirationTime of a snapshot created at createTime that is
+// kept for retention ("HH:mm:ss", hours may exceed 24)
+func snapshotExpiration(createTime, retention string) (string, error) {
+	created, err := time.Parse(time.RFC3339, createTime)
+	if err != nil {
+		return "", fmt.Errorf("invalid snapshot create time %q: %w", createTime, err)
 	}
-	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListGroupSnapshots(context.TODO(), &req)
-	if sdkErr != nil {
-		return nil, sdkErr
+	d, err := parseRetention(retention)
+	if err != nil {
+		return "", err
+	}
+	return created.Add(d).UTC().Format(time.RFC3339), nil
+}
+
+// parseRetention parses an Element retention period of the form HH:mm:ss
+func parseRetention(retention string) (time.Duration, error) {
+	parts := strings.Split(retention, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid retention %q: expected HH:mm:ss", retention)
+	}
+	var n [3]int
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil || v < 0 || (i > 0 && (v > 59 || len(p) != 2)) {
+			return 0, fmt.Errorf("invalid retention %q: expected HH:mm:ss", retention)
+		}
+		n[i] = v
 	}
-	return res.GroupSnapshots, nil
+	return time.Duration(n[0])*time.Hour + time.Duration(n[1])*time.Minute + time.Duration(n[2])*time.Second, nil
 }
@@ -1,9 +1,16 @@
 package solidfire
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 // buildScheduleInfo converts a slice of volume IDs to the correct scheduleInfo field for API requests
-func buildScheduleInfo(volumes []string, retention string, snapMirrorLabel interface{}) map[string]interface{} {
+func buildScheduleInfo(volumes []int64, retention string, snapMirrorLabel interface{}) map[string]interface{} {
 	info := make(map[string]interface{})
-	info["retention"] = retention
+	if retention != "" {
+		info["retention"] = retention
+	}
 	if snapMirrorLabel != nil {
 		info["snapMirrorLabel"] = snapMirrorLabel
 	}
@@ -30,3 +37,67 @@ func toIntSlice(v interface{}) []int {
 	}
 	return out
 }
+
+// toStringSlice converts an interface{} list to []string
+func toStringSlice(v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]string, len(arr))
+	for i, x := range arr {
+		out[i] = x.(string)
+	}
+	return out
+}
+
+// toInt64Slice converts an interface{} list of ints to []int64 for API requests
+func toInt64Slice(v interface{}) []int64 {
+	if v == nil {
+		return nil
+	}
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]int64, len(arr))
+	for i, x := range arr {
+		out[i] = int64(x.(int))
+	}
+	return out
+}
+
+// flattenAttributes converts the attributes of an Element object to a map of strings, encoding
+// values that are not strings as JSON
+func flattenAttributes(attributes map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(attributes))
+	for k, v := range attributes {
+		if s, ok := v.(string); ok {
+			out[k] = s
+			continue
+		}
+		b, _ := json.Marshal(v)
+		out[k] = string(b)
+	}
+	return out
+}
+
+// expandAttributes is the inverse of flattenAttributes: values holding a JSON object or array
+// are sent decoded, everything else as the string it is
+func expandAttributes(attributes map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(attributes))
+	for k, v := range attributes {
+		s, _ := v.(string)
+		out[k] = s
+		if strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(s), &decoded); err == nil {
+				out[k] = decoded
+			}
+		}
+	}
+	return out
+}
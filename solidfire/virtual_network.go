@@ -0,0 +1,91 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type virtualNetworkAddressBlock struct {
+	Start     string `json:"start"`
+	Size      int64  `json:"size"`
+	Available string `json:"available,omitempty"`
+}
+
+type virtualNetwork struct {
+	VirtualNetworkID  int64                        `json:"virtualNetworkID"`
+	VirtualNetworkTag int64                        `json:"virtualNetworkTag"`
+	Name              string                       `json:"name"`
+	AddressBlocks     []virtualNetworkAddressBlock `json:"addressBlocks"`
+	Netmask           string                       `json:"netmask"`
+	Svip              string                       `json:"svip"`
+	Gateway           string                       `json:"gateway"`
+	Namespace         bool                         `json:"namespace"`
+	Attributes        map[string]interface{}       `json:"attributes"`
+}
+
+type listVirtualNetworksResult struct {
+	VirtualNetworks []virtualNetwork `json:"virtualNetworks"`
+}
+
+func (c *Client) AddVirtualNetwork(params map[string]interface{}) (int64, error) {
+	raw, err := c.CallAPIMethod("AddVirtualNetwork", params)
+	if err != nil {
+		return 0, err
+	}
+	var res struct {
+		VirtualNetworkID int64 `json:"virtualNetworkID"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return 0, fmt.Errorf("error parsing AddVirtualNetwork: %s", err)
+	}
+	return res.VirtualNetworkID, nil
+}
+
+func (c *Client) ModifyVirtualNetwork(params map[string]interface{}) error {
+	_, err := c.CallAPIMethod("ModifyVirtualNetwork", params)
+	return err
+}
+
+func (c *Client) RemoveVirtualNetwork(id int64) error {
+	_, err := c.CallAPIMethod("RemoveVirtualNetwork", map[string]interface{}{
+		"virtualNetworkID": id,
+	})
+	return err
+}
+
+// GetVirtualNetwork returns the virtual network with the given ID, or nil if it does not exist
+func (c *Client) GetVirtualNetwork(id int64) (*virtualNetwork, error) {
+	raw, err := c.CallAPIMethod("ListVirtualNetworks", map[string]interface{}{
+		"virtualNetworkIDs": []int64{id},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res listVirtualNetworksResult
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListVirtualNetworks: %s", err)
+	}
+	for _, vn := range res.VirtualNetworks {
+		if vn.VirtualNetworkID == id {
+			return &vn, nil
+		}
+	}
+	return nil, nil
+}
+
+// SetInitiatorVirtualNetworks restricts an initiator to the given virtual network IDs.
+// The SDK's ModifyInitiator struct has no virtualNetworkIDs field, so this goes through CallAPIMethod.
+func (c *Client) SetInitiatorVirtualNetworks(initiatorID int64, virtualNetworkIDs []int64) error {
+	if virtualNetworkIDs == nil {
+		virtualNetworkIDs = []int64{}
+	}
+	_, err := c.CallAPIMethod("ModifyInitiators", map[string]interface{}{
+		"initiators": []map[string]interface{}{
+			{
+				"initiatorID":       initiatorID,
+				"virtualNetworkIDs": virtualNetworkIDs,
+			},
+		},
+	})
+	return err
+}
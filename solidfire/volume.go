@@ -1,7 +1,6 @@
 package solidfire
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/scaleoutsean/solidfire-go/sdk"
@@ -13,7 +12,7 @@ func (c *Client) ListVolumes(volumeIDs []int64) ([]sdk.Volume, error) {
 		req.VolumeIDs = volumeIDs
 	}
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListVolumes(context.TODO(), &req)
+	res, sdkErr := callSDK(c, "ListVolumes", (*sdk.SFClient).ListVolumes, &req)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -22,7 +21,7 @@ func (c *Client) ListVolumes(volumeIDs []int64) ([]sdk.Volume, error) {
 
 func (c *Client) ModifyVolume(req *sdk.ModifyVolumeRequest) error {
 	c.initOnce.Do(c.init)
-	_, sdkErr := c.sdkClient.ModifyVolume(context.TODO(), req)
+	_, sdkErr := callSDK(c, "ModifyVolume", (*sdk.SFClient).ModifyVolume, req)
 	if sdkErr != nil {
 		return sdkErr
 	}
@@ -31,14 +30,22 @@ func (c *Client) ModifyVolume(req *sdk.ModifyVolumeRequest) error {
 
 func (c *Client) ListActiveVolumes(req *sdk.ListActiveVolumesRequest) ([]sdk.Volume, error) {
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListActiveVolumes(context.TODO(), req)
+	res, sdkErr := callSDK(c, "ListActiveVolumes", (*sdk.SFClient).ListActiveVolumes, req)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
 	return res.Volumes, nil
 }
 
+// GetVolume returns a volume by ID, from the read cache when enabled. Deleted volumes are
+// not part of the cached inventory and are always looked up directly.
 func (c *Client) GetVolume(volumeID int64) (*sdk.Volume, error) {
+	c.initOnce.Do(c.init)
+	if c.reads != nil {
+		if vol, ok := fromReadCache(c, "volumes", &c.reads.volumes, c.sweepActiveVolumes, volumeID); ok {
+			return &vol, nil
+		}
+	}
 	vols, err := c.ListVolumes([]int64{volumeID})
 	if err != nil {
 		return nil, err
@@ -54,7 +61,7 @@ func (c *Client) ListVolumesForAccount(accountID int64) ([]sdk.Volume, error) {
 		AccountID: accountID,
 	}
 	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListVolumesForAccount(context.TODO(), &req)
+	res, sdkErr := callSDK(c, "ListVolumesForAccount", (*sdk.SFClient).ListVolumesForAccount, &req)
 	if sdkErr != nil {
 		return nil, sdkErr
 	}
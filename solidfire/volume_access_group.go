@@ -1,11 +1,8 @@
 package solidfire
 
 import (
-	"context"
 	"fmt"
 	"strconv"
-
-	"github.com/scaleoutsean/solidfire-go/sdk"
 )
 
 type volumeAccessGroup struct {
@@ -22,20 +19,16 @@ func (c *Client) getVolumeAccessGroupByID(id string) (volumeAccessGroup, error)
 		return volumeAccessGroup{}, err
 	}
 
-	req := sdk.ListVolumeAccessGroupsRequest{
-		VolumeAccessGroups: []int64{convID},
-	}
-	c.initOnce.Do(c.init)
-	res, sdkErr := c.sdkClient.ListVolumeAccessGroups(context.TODO(), &req)
-	if sdkErr != nil {
-		return volumeAccessGroup{}, sdkErr
+	vags, err := c.listVolumeAccessGroupsByID(convID)
+	if err != nil {
+		return volumeAccessGroup{}, err
 	}
 
-	if len(res.VolumeAccessGroups) != 1 {
+	if len(vags) != 1 {
 		return volumeAccessGroup{}, fmt.Errorf("expected one volume access group to be found")
 	}
 
-	vag := res.VolumeAccessGroups[0]
+	vag := vags[0]
 	return volumeAccessGroup{
 		VolumeAccessGroupID: vag.VolumeAccessGroupID,
 		Name:                vag.Name,
@@ -0,0 +1,92 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// volumePair is an entry of a volume's volumePairs, seen from the local side
+type volumePair struct {
+	ClusterPairID     int64             `json:"clusterPairID"`
+	RemoteVolumeID    int64             `json:"remoteVolumeID"`
+	RemoteVolumeName  string            `json:"remoteVolumeName"`
+	VolumePairUUID    string            `json:"volumePairUUID"`
+	RemoteReplication remoteReplication `json:"remoteReplication"`
+}
+
+type remoteReplication struct {
+	Mode                string `json:"mode"`
+	State               string `json:"state"`
+	StateDetails        string `json:"stateDetails"`
+	ResumeDetails       string `json:"resumeDetails"`
+	SnapshotReplication struct {
+		State        string `json:"state"`
+		StateDetails string `json:"stateDetails"`
+	} `json:"snapshotReplication"`
+}
+
+// pausedManually reports whether an administrator paused the pair on this cluster, which is
+// what pausedManual of ModifyVolumePair controls. A pause on the remote cluster is
+// PausedManualRemote and is only reported as the replication state.
+func (r remoteReplication) pausedManually() bool {
+	return r.State == "PausedManual"
+}
+
+// pausedAutomatically reports whether the cluster paused the pair, e.g. PausedDisconnected
+// or PausedMisconfigured
+func (r remoteReplication) pausedAutomatically() bool {
+	return strings.HasPrefix(r.State, "Paused") && !strings.HasPrefix(r.State, "PausedManual")
+}
+
+type pairedVolume struct {
+	VolumeID    int64        `json:"volumeID"`
+	Name        string       `json:"name"`
+	VolumePairs []volumePair `json:"volumePairs"`
+}
+
+// getPairedVolume returns the volume with its pairs, or nil when the volume is not paired
+func (c *Client) getPairedVolume(volumeID int64) (*pairedVolume, error) {
+	raw, err := c.CallAPIMethod("ListActivePairedVolumes", map[string]interface{}{
+		"startVolumeID": volumeID,
+		"limit":         1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Volumes []pairedVolume `json:"volumes"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListActivePairedVolumes: %s", err)
+	}
+	if len(res.Volumes) == 0 || res.Volumes[0].VolumeID != volumeID || len(res.Volumes[0].VolumePairs) == 0 {
+		return nil, nil
+	}
+	return &res.Volumes[0], nil
+}
+
+// parseAsyncDelay turns the asyncDelay of volume stats ("HH:MM:SS.ffffff", hours may exceed 24)
+// into a duration
+func parseAsyncDelay(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("unexpected asyncDelay %q", s)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("unexpected asyncDelay %q", s)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("unexpected asyncDelay %q", s)
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected asyncDelay %q", s)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second)), nil
+}
@@ -0,0 +1,42 @@
+package solidfire
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAsyncDelay(t *testing.T) {
+	cases := map[string]time.Duration{
+		"00:00:00.000000": 0,
+		"00:01:30.500000": 90*time.Second + 500*time.Millisecond,
+		"26:00:05":        26*time.Hour + 5*time.Second,
+	}
+	for in, want := range cases {
+		got, err := parseAsyncDelay(in)
+		if err != nil || got != want {
+			t.Errorf("parseAsyncDelay(%q) = %s, %v; want %s", in, got, err, want)
+		}
+	}
+	if _, err := parseAsyncDelay("soon"); err == nil {
+		t.Error("expected an error for a malformed asyncDelay")
+	}
+}
+
+func TestRemoteReplicationPauseState(t *testing.T) {
+	cases := []struct {
+		state        string
+		manual, auto bool
+	}{
+		{"Active", false, false},
+		{"PausedManual", true, false},
+		{"PausedManualRemote", false, false},
+		{"PausedDisconnected", false, true},
+		{"PausedMisconfigured", false, true},
+	}
+	for _, c := range cases {
+		r := remoteReplication{State: c.state}
+		if r.pausedManually() != c.manual || r.pausedAutomatically() != c.auto {
+			t.Errorf("%s: manual=%v automatic=%v, want %v %v", c.state, r.pausedManually(), r.pausedAutomatically(), c.manual, c.auto)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package solidfire
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type volumeStats struct {
+	VolumeID             int64   `json:"volumeID"`
+	AccountID            int64   `json:"accountID"`
+	ActualIOPS           int64   `json:"actualIOPS"`
+	AverageIOPSize       int64   `json:"averageIOPSize"`
+	BurstIOPSCredit      int64   `json:"burstIOPSCredit"`
+	ClientQueueDepth     int64   `json:"clientQueueDepth"`
+	LatencyUSec          int64   `json:"latencyUSec"`
+	ReadLatencyUSec      int64   `json:"readLatencyUSec"`
+	WriteLatencyUSec     int64   `json:"writeLatencyUSec"`
+	NonZeroBlocks        int64   `json:"nonZeroBlocks"`
+	ZeroBlocks           int64   `json:"zeroBlocks"`
+	NormalizedIOPS       int64   `json:"normalizedIOPS"`
+	ReadBytes            int64   `json:"readBytes"`
+	ReadBytesLastSample  int64   `json:"readBytesLastSample"`
+	ReadOps              int64   `json:"readOps"`
+	ReadOpsLastSample    int64   `json:"readOpsLastSample"`
+	WriteBytes           int64   `json:"writeBytes"`
+	WriteBytesLastSample int64   `json:"writeBytesLastSample"`
+	WriteOps             int64   `json:"writeOps"`
+	WriteOpsLastSample   int64   `json:"writeOpsLastSample"`
+	SamplePeriodMSec     int64   `json:"samplePeriodMSec"`
+	Throttle             float64 `json:"throttle"`
+	VolumeSize           int64   `json:"volumeSize"`
+	VolumeUtilization    float64 `json:"volumeUtilization"`
+	Timestamp            string  `json:"timestamp"`
+	// AsyncDelay is the time since the volume was last synced to its remote pair, "HH:MM:SS.ffffff"
+	AsyncDelay *string `json:"asyncDelay"`
+}
+
+type volumeEfficiency struct {
+	Compression      float64 `json:"compression"`
+	Deduplication    float64 `json:"deduplication"`
+	ThinProvisioning float64 `json:"thinProvisioning"`
+}
+
+// ListVolumeStats returns stats for the given volumes, or for every volume when volumeIDs is empty
+func (c *Client) ListVolumeStats(volumeIDs []int64) ([]volumeStats, error) {
+	params := map[string]interface{}{}
+	if len(volumeIDs) > 0 {
+		params["volumeIDs"] = volumeIDs
+	}
+	raw, err := c.CallAPIMethod("ListVolumeStats", params)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		VolumeStats []volumeStats `json:"volumeStats"`
+	}
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing ListVolumeStats: %s", err)
+	}
+	return res.VolumeStats, nil
+}
+
+func (c *Client) GetVolumeEfficiency(volumeID int64) (*volumeEfficiency, error) {
+	raw, err := c.CallAPIMethod("GetVolumeEfficiency", map[string]interface{}{
+		"volumeID": volumeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res volumeEfficiency
+	if err := json.Unmarshal([]byte(*raw), &res); err != nil {
+		return nil, fmt.Errorf("error parsing GetVolumeEfficiency: %s", err)
+	}
+	return &res, nil
+}
+
+// throughput returns the read and write bytes per second over the last sample period
+func (s volumeStats) throughput() (float64, float64) {
+	if s.SamplePeriodMSec <= 0 {
+		return 0, 0
+	}
+	seconds := float64(s.SamplePeriodMSec) / 1000
+	return float64(s.ReadBytesLastSample) / seconds, float64(s.WriteBytesLastSample) / seconds
+}